@@ -3,13 +3,17 @@ package main
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/jessevdk/go-flags"
 )
 
+var excludedElementPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*(\[[a-zA-Z][a-zA-Z0-9:-]*\])?$`)
+
 type arguments struct {
 	RawAcceptedStatusCodes string   `long:"accepted-status-codes" value-name:"<codes>" default:"200..300" description:"Accepted HTTP response status codes (e.g. '200..300,403')"`
 	BufferSize             int      `short:"b" long:"buffer-size" value-name:"<size>" default:"4096" description:"HTTP response buffer size in bytes"`
@@ -21,31 +25,59 @@ type arguments struct {
 	FollowRobotsTxt        bool     `long:"follow-robots-txt" description:"Follow robots.txt when scraping pages"`
 	FollowSitemapXML       bool     `long:"follow-sitemap-xml" description:"Scrape only pages listed in sitemap.xml (deprecated)"`
 	RawHeaders             []string `long:"header" value-name:"<header>..." description:"Custom headers"`
+	ExcludedElements       []string `long:"exclude-element" value-name:"<element>..." description:"Exclude links in given elements or attributes (e.g. 'form' or 'blockquote[cite]')"`
+	RawScanSelector        string   `long:"scan-selector" value-name:"<selector>" description:"Find links only in elements matched with a CSS selector (e.g. 'main')"`
+	RawIgnoreSelector      string   `long:"ignore-selector" value-name:"<selector>" description:"Ignore links in elements matched with a CSS selector (e.g. 'footer .widget')"`
+	RawLinkAttributeRules  []string `long:"link-attribute" value-name:"<selector>..." description:"Find links in attributes of elements matched with CSS selectors additionally (e.g. 'img[data-src]' or 'img[data-srcset] srcset')"`
 	// TODO Remove a short option.
-	IgnoreFragments bool   `short:"f" long:"ignore-fragments" description:"Ignore URL fragments"`
-	DnsResolver     string `long:"dns-resolver" value-name:"<address>" description:"Custom DNS resolver"`
-	Format          string `long:"format" description:"Output format" default:"text" choice:"text" choice:"json" choice:"junit"`
+	IgnoreFragments     bool     `short:"f" long:"ignore-fragments" description:"Ignore URL fragments"`
+	RawFragmentPolicies []string `long:"fragment-policy" value-name:"<policy>..." description:"Check, ignore, or ignore-matching fragments of URLs matched with given regular expressions (e.g. '^https://foo.com/app/ ignore-matching ^/')"`
+	NormalizeURLs       bool     `long:"normalize-urls" description:"Normalize URLs to check equivalent ones only once"`
+	TrackingParameters  []string `long:"tracking-parameter" value-name:"<pattern>..." default:"utm_*" default:"fbclid" default:"gclid" description:"Strip query parameters matched with glob patterns on URL normalization"`
+	DnsResolver         string   `long:"dns-resolver" value-name:"<address>" description:"Custom DNS resolver"`
+	Format              string   `long:"format" description:"Output format" choice:"text" choice:"json" choice:"junit" choice:"csv" choice:"github" choice:"gitlab-codequality" choice:"tap" default:"text"`
+	RawOutputs          []string `long:"output" value-name:"<format>=<path>..." description:"Write results in given formats into files additionally (e.g. 'junit=report.xml')"`
 	// TODO Remove this option.
 	JSONOutput bool `long:"json" description:"Output results in JSON (deprecated)"`
 	// TODO Remove this option.
 	VerboseJSON bool `long:"experimental-verbose-json" description:"Include successful results in JSON (deprecated)"`
 	// TODO Remove this option.
-	JUnitOutput         bool   `long:"junit" description:"Output results as JUnit XML file (deprecated)"`
-	MaxRedirections     int    `short:"r" long:"max-redirections" value-name:"<count>" default:"64" description:"Maximum number of redirections"`
-	RateLimit           int    `long:"rate-limit" value-name:"<rate>" description:"Max requests per second"`
-	Timeout             int    `short:"t" long:"timeout" value-name:"<seconds>" default:"10" description:"Timeout for HTTP requests in seconds"`
-	Verbose             bool   `short:"v" long:"verbose" description:"Show successful results too"`
-	Proxy               string `long:"proxy" value-name:"<host>" description:"HTTP proxy host"`
-	SkipTLSVerification bool   `long:"skip-tls-verification" description:"Skip TLS certificate verification"`
-	OnePageOnly         bool   `long:"one-page-only" description:"Only check links found in the given URL"`
-	Color               color  `long:"color" description:"Color output" choice:"auto" choice:"always" choice:"never" default:"auto"`
-	Help                bool   `short:"h" long:"help" description:"Show this help"`
-	Version             bool   `long:"version" description:"Show version"`
-	URL                 string
-	AcceptedStatusCodes statusCodeSet
-	ExcludedPatterns    []*regexp.Regexp
-	IncludePatterns     []*regexp.Regexp
-	Header              http.Header
+	JUnitOutput              bool          `long:"junit" description:"Output results as JUnit XML file (deprecated)"`
+	FollowMetaRefreshes      bool          `long:"follow-meta-refreshes" description:"Follow meta refreshes of pages as redirections"`
+	CheckMetaRefreshes       bool          `long:"check-meta-refreshes" description:"Warn about links to pages with meta refreshes to broken or redirected pages"`
+	MaxRedirections          int           `short:"r" long:"max-redirections" value-name:"<count>" default:"64" description:"Maximum number of redirections"`
+	RateLimit                int           `long:"rate-limit" value-name:"<rate>" description:"Max requests per second"`
+	Timeout                  int           `short:"t" long:"timeout" value-name:"<seconds>" default:"10" description:"Timeout for HTTP requests in seconds"`
+	Verbose                  bool          `short:"v" long:"verbose" description:"Show successful results too"`
+	Summary                  bool          `long:"summary" description:"Show summary statistics at the end"`
+	GroupBy                  string        `long:"group-by" description:"Group results by pages or broken links" choice:"page" choice:"link" default:"page"`
+	MaxReferrers             int           `long:"max-referrers" value-name:"<count>" description:"Maximum number of pages listed for each link grouped by links"`
+	Quiet                    bool          `long:"quiet" description:"Disable progress display on terminals"`
+	Proxy                    string        `long:"proxy" value-name:"<host>" description:"HTTP proxy host"`
+	SkipTLSVerification      bool          `long:"skip-tls-verification" description:"Skip TLS certificate verification"`
+	OnePageOnly              bool          `long:"one-page-only" description:"Only check links found in the given URL"`
+	SlowThreshold            time.Duration `long:"slow-threshold" value-name:"<duration>" description:"Warn about links slower than a given duration (e.g. '2s')"`
+	WarnPermanentRedirects   bool          `long:"warn-permanent-redirects" description:"Warn about links permanently redirected"`
+	FailOnCrossHostRedirects bool          `long:"fail-on-cross-host-redirects" description:"Fail on links redirected to other hosts"`
+	CheckInsecureLinks       bool          `long:"check-insecure-links" description:"Report HTTP links on HTTPS pages as errors for subresources or warnings otherwise"`
+	ProbeHTTPS               bool          `long:"probe-https" description:"Suggest HTTPS versions of insecure links if available"`
+	CheckSEO                 bool          `long:"check-seo" description:"Warn about broken canonical links and non-reciprocal hreflang alternates"`
+	DeduplicateCanonical     bool          `long:"deduplicate-canonical-pages" description:"Crawl pages with the same canonical URL only once"`
+	FailOn                   string        `long:"fail-on" description:"Lowest severity of link results to fail" choice:"error" choice:"warning" default:"error"`
+	Color                    color         `long:"color" description:"Color output" choice:"auto" choice:"always" choice:"never" default:"auto"`
+	Help                     bool          `short:"h" long:"help" description:"Show this help"`
+	Version                  bool          `long:"version" description:"Show version"`
+	URL                      string
+	AcceptedStatusCodes      statusCodeSet
+	ExcludedPatterns         []*regexp.Regexp
+	IncludePatterns          []*regexp.Regexp
+	Header                   http.Header
+	FragmentPolicies         []*fragmentPolicy
+	LinkAttributeRules       []*linkAttributeRule
+	ScanSelector             cssSelector
+	IgnoreSelector           cssSelector
+	Outputs                  []*output
+	URLNormalizer            *urlNormalizer
 }
 
 func getArguments(ss []string) (*arguments, error) {
@@ -74,6 +106,43 @@ func getArguments(ss []string) (*arguments, error) {
 		return nil, err
 	}
 
+	for _, e := range args.ExcludedElements {
+		if !excludedElementPattern.MatchString(e) {
+			return nil, fmt.Errorf("invalid element: %v", e)
+		}
+	}
+
+	if args.RawScanSelector != "" {
+		args.ScanSelector, err = parseCSSSelector(args.RawScanSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if args.RawIgnoreSelector != "" {
+		args.IgnoreSelector, err = parseCSSSelector(args.RawIgnoreSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	args.LinkAttributeRules, err = parseLinkAttributeRules(args.RawLinkAttributeRules)
+	if err != nil {
+		return nil, err
+	}
+
+	args.FragmentPolicies, err = parseFragmentPolicies(args.RawFragmentPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.NormalizeURLs {
+		args.URLNormalizer, err = newURLNormalizer(args.TrackingParameters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	args.Header, err = parseHeaders(args.RawHeaders)
 	if err != nil {
 		return nil, err
@@ -84,8 +153,23 @@ func getArguments(ss []string) (*arguments, error) {
 		return nil, err
 	}
 
-	if args.Format == "junit" && args.Verbose {
-		return nil, errors.New("verbose option not supported for JUnit output")
+	args.Outputs, err = parseOutputs(args.RawOutputs)
+	if err != nil {
+		return nil, err
+	}
+
+	fs := []string{args.Format}
+
+	for _, o := range args.Outputs {
+		fs = append(fs, o.Format)
+	}
+
+	for _, f := range fs {
+		if f == "junit" && args.Verbose {
+			return nil, errors.New("verbose option not supported for JUnit output")
+		} else if args.GroupBy == "link" && f != "text" && f != "json" {
+			return nil, fmt.Errorf("grouping by links not supported for %v output", f)
+		}
 	}
 
 	return &args, nil
@@ -135,6 +219,51 @@ func parseHeaders(headers []string) (http.Header, error) {
 	return h, nil
 }
 
+func parseLinkAttributeRules(ss []string) ([]*linkAttributeRule, error) {
+	rs := make([]*linkAttributeRule, 0, len(ss))
+
+	for _, s := range ss {
+		r, err := parseLinkAttributeRule(s)
+		if err != nil {
+			return nil, err
+		}
+
+		rs = append(rs, r)
+	}
+
+	return rs, nil
+}
+
+func parseFragmentPolicies(ss []string) ([]*fragmentPolicy, error) {
+	ps := make([]*fragmentPolicy, 0, len(ss))
+
+	for _, s := range ss {
+		p, err := parseFragmentPolicy(s)
+		if err != nil {
+			return nil, err
+		}
+
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
+
+func parseOutputs(ss []string) ([]*output, error) {
+	outputs := make([]*output, 0, len(ss))
+
+	for _, s := range ss {
+		o, err := parseOutput(s)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, o)
+	}
+
+	return outputs, nil
+}
+
 func reconcileDeprecatedArguments(args *arguments) {
 	if args.JSONOutput {
 		args.Format = "json"
@@ -22,8 +22,15 @@ func TestGetArguments(t *testing.T) {
 		{"--exclude", "regex1", "--exclude", "regex2", "https://foo.com"},
 		{"--header", "MyHeader: foo", "--header", "YourHeader: bar", "https://foo.com"},
 		{"--header", "User-Agent: custom-agent", "https://foo.com"},
+		{"--exclude-element", "form", "--exclude-element", "blockquote[cite]", "https://foo.com"},
+		{"--scan-selector", "main", "--ignore-selector", "footer .widget, aside", "https://foo.com"},
+		{"--normalize-urls", "https://foo.com"},
+		{"--normalize-urls", "--tracking-parameter", "utm_*", "--tracking-parameter", "ref", "https://foo.com"},
+		{"--link-attribute", "img[data-src]", "--link-attribute", "img[data-srcset] srcset", "https://foo.com"},
 		{"-r", "4", "https://foo.com"},
 		{"--max-redirections", "4", "https://foo.com"},
+		{"--follow-meta-refreshes", "https://foo.com"},
+		{"--check-meta-refreshes", "https://foo.com"},
 		{"--follow-robots-txt", "https://foo.com"},
 		{"--follow-sitemap-xml", "https://foo.com"},
 		{"-t", "10", "https://foo.com"},
@@ -33,10 +40,32 @@ func TestGetArguments(t *testing.T) {
 		{"--skip-tls-verification", "https://foo.com"},
 		{"-v", "https://foo.com"},
 		{"--verbose", "https://foo.com"},
+		{"--summary", "https://foo.com"},
+		{"--quiet", "https://foo.com"},
+		{"--group-by", "link", "https://foo.com"},
+		{"--group-by", "link", "--format", "json", "https://foo.com"},
+		{"--group-by", "link", "--max-referrers", "3", "https://foo.com"},
+		{"--group-by", "link", "--output", "json=links.json", "https://foo.com"},
 		{"-v", "-f", "https://foo.com"},
 		{"-v", "--ignore-fragments", "https://foo.com"},
+		{"--fragment-policy", "^https://foo.com/app ignore-matching ^/", "https://foo.com"},
+		{"--fragment-policy", "foo ignore", "--fragment-policy", ". check", "https://foo.com"},
 		{"--one-page-only", "https://foo.com"},
+		{"--slow-threshold", "2s", "https://foo.com"},
+		{"--warn-permanent-redirects", "https://foo.com"},
+		{"--fail-on-cross-host-redirects", "https://foo.com"},
+		{"--fail-on", "warning", "https://foo.com"},
+		{"--check-insecure-links", "https://foo.com"},
+		{"--check-insecure-links", "--probe-https", "https://foo.com"},
+		{"--check-seo", "https://foo.com"},
+		{"--deduplicate-canonical-pages", "https://foo.com"},
 		{"--json", "https://foo.com"},
+		{"--format", "csv", "https://foo.com"},
+		{"--format", "github", "https://foo.com"},
+		{"--format", "gitlab-codequality", "https://foo.com"},
+		{"--format", "tap", "https://foo.com"},
+		{"--output", "junit=report.xml", "https://foo.com"},
+		{"--output", "junit=report.xml", "--output", "json=links.json", "https://foo.com"},
 		{"-h"},
 		{"--help"},
 		{"--version"},
@@ -61,6 +90,26 @@ func TestGetArgumentsError(t *testing.T) {
 		{"--max-redirections", "foo", "https://foo.com"},
 		{"-t", "foo", "https://foo.com"},
 		{"--timeout", "foo", "https://foo.com"},
+		{"--format", "foo", "https://foo.com"},
+		{"--slow-threshold", "foo", "https://foo.com"},
+		{"--output", "junit", "https://foo.com"},
+		{"--output", "foo=report.xml", "https://foo.com"},
+		{"--group-by", "foo", "https://foo.com"},
+		{"--group-by", "link", "--format", "junit", "https://foo.com"},
+		{"--group-by", "link", "--output", "csv=links.csv", "https://foo.com"},
+		{"--verbose", "--output", "junit=report.xml", "https://foo.com"},
+		{"--max-referrers", "foo", "https://foo.com"},
+		{"--fail-on", "foo", "https://foo.com"},
+		{"--exclude-element", "form[", "https://foo.com"},
+		{"--link-attribute", "img", "https://foo.com"},
+		{"--scan-selector", "main >", "https://foo.com"},
+		{"--ignore-selector", "[", "https://foo.com"},
+		{"--normalize-urls", "--tracking-parameter", "utm_[", "https://foo.com"},
+		{"--link-attribute", "img[", "https://foo.com"},
+		{"--exclude-element", "a[href] img", "https://foo.com"},
+		{"--fragment-policy", "foo", "https://foo.com"},
+		{"--fragment-policy", "foo bar", "https://foo.com"},
+		{"--fragment-policy", "( ignore", "https://foo.com"},
 	} {
 		_, err := getArguments(ss)
 		assert.NotNil(t, err)
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"net/http"
 	"net/url"
 )
@@ -20,7 +19,7 @@ func (c *checkedHttpClient) Get(u *url.URL, header http.Header) (httpResponse, e
 	if err != nil {
 		return nil, err
 	} else if code := r.StatusCode(); !c.acceptedStatusCodes.Contains(code) {
-		return nil, fmt.Errorf("%v", code)
+		return nil, newStatusCodeError(code)
 	}
 
 	return r, nil
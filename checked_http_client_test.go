@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"net/url"
 	"testing"
 
@@ -42,3 +43,22 @@ func TestCheckedHttpClientFailWithInvalidStatusCode(t *testing.T) {
 	assert.Nil(t, r)
 	assert.Equal(t, err.Error(), "404")
 }
+
+func TestCheckedHttpClientReturnStatusCodeError(t *testing.T) {
+	u, err := url.Parse(testUrl)
+
+	assert.Nil(t, err)
+
+	_, err = newCheckedHttpClient(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeHttpResponse(404, testUrl, nil, nil), nil
+			},
+		),
+		statusCodeSet{{200, 201}: {}},
+	).Get(u, nil)
+
+	e := &statusCodeError{}
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, 404, e.StatusCode())
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/logrusorgru/aurora/v3"
@@ -16,11 +19,12 @@ import (
 type command struct {
 	stdout, stderr    io.Writer
 	terminal          bool
+	stderrTerminal    bool
 	httpClientFactory httpClientFactory
 }
 
-func newCommand(stdout, stderr io.Writer, terminal bool, f httpClientFactory) *command {
-	return &command{stdout, stderr, terminal, f}
+func newCommand(stdout, stderr io.Writer, terminal, stderrTerminal bool, f httpClientFactory) *command {
+	return &command{stdout, stderr, terminal, stderrTerminal, f}
 }
 
 func (c *command) Run(args []string) bool {
@@ -44,27 +48,28 @@ func (c *command) runWithError(ss []string) (bool, error) {
 		return true, nil
 	}
 
-	client := newCheckedHttpClient(
-		newRedirectHttpClient(
-			newThrottledHttpClient(
-				c.httpClientFactory.Create(
-					httpClientOptions{
-						MaxConnectionsPerHost: args.MaxConnectionsPerHost,
-						MaxResponseBodySize:   args.MaxResponseBodySize,
-						BufferSize:            args.BufferSize,
-						Proxy:                 args.Proxy,
-						SkipTLSVerification:   args.SkipTLSVerification,
-						Timeout:               time.Duration(args.Timeout) * time.Second,
-						Header:                args.Header,
-						DnsResolver:           args.DnsResolver,
-					},
-				),
-				args.RateLimit,
-				args.MaxConnections,
-				args.MaxConnectionsPerHost,
-			),
-			args.MaxRedirections,
+	t := time.Now()
+
+	tc := newThrottledHttpClient(
+		c.httpClientFactory.Create(
+			httpClientOptions{
+				MaxConnectionsPerHost: args.MaxConnectionsPerHost,
+				MaxResponseBodySize:   args.MaxResponseBodySize,
+				BufferSize:            args.BufferSize,
+				Proxy:                 args.Proxy,
+				SkipTLSVerification:   args.SkipTLSVerification,
+				Timeout:               time.Duration(args.Timeout) * time.Second,
+				Header:                args.Header,
+				DnsResolver:           args.DnsResolver,
+			},
 		),
+		args.RateLimit,
+		args.MaxConnections,
+		args.MaxConnectionsPerHost,
+	)
+
+	client := newCheckedHttpClient(
+		newRedirectHttpClient(tc, args.MaxRedirections),
 		args.AcceptedStatusCodes,
 	)
 
@@ -74,20 +79,38 @@ func (c *command) runWithError(ss []string) (bool, error) {
 		client,
 		[]pageParser{
 			newSitemapPageParser(fl),
-			newHtmlPageParser(newLinkFinder(fl)),
+			newHtmlPageParser(
+				newLinkFinder(
+					fl,
+					linkFinderOptions{
+						args.ExcludedElements,
+						args.LinkAttributeRules,
+						args.ScanSelector,
+						args.IgnoreSelector,
+						args.URLNormalizer,
+					},
+				),
+			),
+			newCSSPageParser(newCSSLinkFinder(fl, args.URLNormalizer)),
 		},
 		linkFetcherOptions{
 			args.IgnoreFragments,
+			args.FragmentPolicies,
+			args.FollowMetaRefreshes,
+			fl,
+			args.URLNormalizer,
 		},
 	)
 
-	_, p, err := f.Fetch(args.URL)
+	r, err := f.Fetch(args.URL)
 	if err != nil {
 		return false, fmt.Errorf("failed to fetch root page: %v", err)
-	} else if p == nil {
+	} else if r.Page == nil {
 		return false, errors.New("root page has invalid content type")
 	}
 
+	p := r.Page
+
 	rd := (*robotstxt.RobotsData)(nil)
 
 	if args.FollowRobotsTxt {
@@ -111,75 +134,328 @@ func (c *command) runWithError(ss []string) (bool, error) {
 	checker := newPageChecker(
 		f,
 		newLinkValidator(p.URL().Hostname(), rd, sm),
-		args.OnePageOnly,
+		pageCheckerOptions{
+			OnePageOnly:              args.OnePageOnly,
+			SlowThreshold:            args.SlowThreshold,
+			WarnPermanentRedirects:   args.WarnPermanentRedirects,
+			FailOnCrossHostRedirects: args.FailOnCrossHostRedirects,
+			CheckInsecureLinks:       args.CheckInsecureLinks,
+			ProbeHTTPS:               args.ProbeHTTPS,
+			CheckMetaRefreshes:       args.CheckMetaRefreshes,
+			LinkFilterer:             fl,
+			CheckSEO:                 args.CheckSEO,
+			DeduplicateCanonical:     args.DeduplicateCanonical,
+			URLNormalizer:            args.URLNormalizer,
+		},
 	)
 
+	fs := make([]*os.File, 0, len(args.Outputs))
+
+	for _, o := range args.Outputs {
+		f, err := os.Create(o.Path)
+		if err != nil {
+			closeFiles(fs)
+			return false, err
+		}
+
+		fs = append(fs, f)
+	}
+
+	stdout := c.stdout
+
+	if c.stderrTerminal && !args.Quiet {
+		pi := newProgressIndicator(
+			c.stderr,
+			func() progressStatus {
+				return progressStatus{
+					CheckedPages:     checker.CheckedPages(),
+					PendingPages:     checker.PendingPages(),
+					Requests:         tc.Requests(),
+					InFlightRequests: tc.InFlightRequests(),
+					Errors:           checker.Errors(),
+				}
+			},
+			progressInterval,
+		)
+
+		pi.Start()
+		defer pi.Stop()
+
+		stdout = pi.Writer(stdout)
+	}
+
 	go checker.Check(p)
 
-	switch args.Format {
+	rcs := fanOutPageResults(checker.Results(), len(args.Outputs)+1)
+	errs := make([]error, len(args.Outputs))
+	g := &sync.WaitGroup{}
+
+	for i, o := range args.Outputs {
+		g.Add(1)
+
+		go func() {
+			defer g.Done()
+			defer drainPageResults(rcs[i+1])
+
+			_, err := c.printResults(fs[i], o.Format, rcs[i+1], args, false, t)
+			errs[i] = errors.Join(err, fs[i].Close())
+		}()
+	}
+
+	ok, err := c.printResults(stdout, args.Format, rcs[0], args, c.terminal, t)
+	drainPageResults(rcs[0])
+	g.Wait()
+
+	if err := errors.Join(append([]error{err}, errs...)...); err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func closeFiles(fs []*os.File) {
+	for _, f := range fs {
+		f.Close() // nolint:errcheck
+	}
+}
+
+func (c *command) printResults(
+	w io.Writer,
+	format string,
+	rc <-chan *pageResult,
+	args *arguments,
+	terminal bool,
+	startTime time.Time,
+) (bool, error) {
+	sc := (*summaryCollector)(nil)
+
+	if args.Summary {
+		sc = newSummaryCollector(startTime)
+	}
+
+	warned := false
+	rc = observePageResults(rc, func(r *pageResult) {
+		warned = warned || r.Warned()
+	})
+
+	ok, err := c.printResultsInFormat(w, format, rc, args, terminal, sc)
+	drainPageResults(rc)
+
+	return ok && !(args.FailOn == "warning" && warned), err
+}
+
+func (c *command) printResultsInFormat(
+	w io.Writer,
+	format string,
+	rc <-chan *pageResult,
+	args *arguments,
+	terminal bool,
+	sc *summaryCollector,
+) (bool, error) {
+	if args.GroupBy == "link" {
+		switch format {
+		case "json":
+			return c.printLinkGroupsInJSON(w, rc, args.MaxReferrers, sc)
+		case "text":
+			return c.printLinkGroupsInText(w, rc, args.MaxReferrers, isColorEnabled(args.Color, terminal), sc)
+		}
+	}
+
+	switch format {
 	case "json":
-		return c.printResultsInJSON(checker.Results(), args.Verbose)
+		return c.printResultsInJSON(w, rc, args.Verbose, sc)
 	case "junit":
-		return c.printResultsInJUnitXML(checker.Results())
+		return c.printResultsInJUnitXML(w, rc, sc)
+	case "csv":
+		return c.printResultsInCSV(w, rc)
+	case "github":
+		return c.printResultsInGitHubWorkflowCommands(w, rc)
+	case "gitlab-codequality":
+		return c.printResultsInGitLabCodeQuality(w, rc)
+	case "tap":
+		return c.printResultsInTAP(w, rc)
 	}
 
-	formatter := newPageResultFormatter(
-		args.Verbose,
-		isColorEnabled(args.Color, c.terminal),
-	)
+	return c.printResultsInText(w, rc, args.Verbose, isColorEnabled(args.Color, terminal), sc)
+}
 
+func (c *command) printResultsInText(
+	w io.Writer,
+	rc <-chan *pageResult,
+	verbose, color bool,
+	sc *summaryCollector,
+) (bool, error) {
+	formatter := newPageResultFormatter(verbose, color)
 	ok := true
 
-	for r := range checker.Results() {
-		if !r.OK() || args.Verbose {
-			c.print(formatter.Format(r))
+	for r := range rc {
+		if !r.OK() || r.Warned() || verbose {
+			if err := c.fprint(w, formatter.Format(r)); err != nil {
+				return false, err
+			}
+		}
+
+		if sc != nil {
+			sc.Add(r)
 		}
 
 		ok = ok && r.OK()
 	}
 
+	if sc != nil {
+		if err := c.fprint(w, formatter.FormatSummary(sc.Summary())); err != nil {
+			return false, err
+		}
+	}
+
 	return ok, nil
 }
 
-func (c *command) printResultsInJSON(rc <-chan *pageResult, verbose bool) (bool, error) {
+func (c *command) printResultsInJSON(
+	w io.Writer,
+	rc <-chan *pageResult,
+	verbose bool,
+	sc *summaryCollector,
+) (bool, error) {
 	rs := []any{}
 	ok := true
 
 	for r := range rc {
-		if !r.OK() || verbose {
+		if !r.OK() || r.Warned() || verbose {
 			rs = append(rs, newJSONPageResult(r, verbose))
 		}
 
+		if sc != nil {
+			sc.Add(r)
+		}
+
 		ok = ok && r.OK()
 	}
 
-	bs, err := json.Marshal(rs)
+	x := any(rs)
+
+	if sc != nil {
+		x = struct {
+			Pages   []any        `json:"pages"`
+			Summary *jsonSummary `json:"summary"`
+		}{rs, newJSONSummary(sc.Summary())}
+	}
+
+	bs, err := json.Marshal(x)
 
 	if err != nil {
 		return false, err
 	}
 
-	c.print(string(bs))
+	if err := c.fprint(w, string(bs)); err != nil {
+		return false, err
+	}
 
 	return ok, nil
 }
 
-func (c *command) printResultsInJUnitXML(rc <-chan *pageResult) (bool, error) {
+func (c *command) printLinkGroupsInText(
+	w io.Writer,
+	rc <-chan *pageResult,
+	maxReferrers int,
+	color bool,
+	sc *summaryCollector,
+) (bool, error) {
+	formatter := newPageResultFormatter(false, color)
+	gc := c.collectLinkGroups(rc, maxReferrers, sc)
+
+	for _, g := range gc.Groups() {
+		if err := c.fprint(w, formatter.FormatLinkGroup(g)); err != nil {
+			return false, err
+		}
+	}
+
+	if sc != nil {
+		if err := c.fprint(w, formatter.FormatSummary(sc.Summary())); err != nil {
+			return false, err
+		}
+	}
+
+	return gc.OK(), nil
+}
+
+func (c *command) printLinkGroupsInJSON(
+	w io.Writer,
+	rc <-chan *pageResult,
+	maxReferrers int,
+	sc *summaryCollector,
+) (bool, error) {
+	gc := c.collectLinkGroups(rc, maxReferrers, sc)
+	gs := []*jsonLinkGroup{}
+
+	for _, g := range gc.Groups() {
+		gs = append(gs, newJSONLinkGroup(g))
+	}
+
+	x := any(gs)
+
+	if sc != nil {
+		x = struct {
+			Links   []*jsonLinkGroup `json:"links"`
+			Summary *jsonSummary     `json:"summary"`
+		}{gs, newJSONSummary(sc.Summary())}
+	}
+
+	bs, err := json.Marshal(x)
+	if err != nil {
+		return false, err
+	}
+
+	if err := c.fprint(w, string(bs)); err != nil {
+		return false, err
+	}
+
+	return gc.OK(), nil
+}
+
+func (*command) collectLinkGroups(rc <-chan *pageResult, maxReferrers int, sc *summaryCollector) *linkGroupCollector {
+	gc := newLinkGroupCollector(maxReferrers)
+
+	for r := range rc {
+		gc.Add(r)
+
+		if sc != nil {
+			sc.Add(r)
+		}
+	}
+
+	return gc
+}
+
+func (c *command) printResultsInJUnitXML(w io.Writer, rc <-chan *pageResult, sc *summaryCollector) (bool, error) {
 	rs := []*xmlPageResult{}
 	ok := true
 
 	for r := range rc {
 		rs = append(rs, newXMLPageResult(r))
+
+		if sc != nil {
+			sc.Add(r)
+		}
+
 		ok = ok && r.OK()
 	}
 
+	ps := (*xmlProperties)(nil)
+
+	if sc != nil {
+		ps = newXMLProperties(newXMLSummaryProperties(sc.Summary()))
+	}
+
 	bs, err := xml.MarshalIndent(
 		struct {
 			// spell-checker: disable-next-line
-			XMLName xml.Name `xml:"testsuites"`
+			XMLName    xml.Name       `xml:"testsuites"`
+			Properties *xmlProperties `xml:"properties"`
 			// spell-checker: disable-next-line
 			PageResults []*xmlPageResult `xml:"testsuite"`
 		}{
+			Properties:  ps,
 			PageResults: rs,
 		},
 		"",
@@ -190,18 +466,103 @@ func (c *command) printResultsInJUnitXML(rc <-chan *pageResult) (bool, error) {
 		return false, err
 	}
 
-	c.print(xml.Header)
-	c.print(string(bs))
+	if err := c.fprint(w, xml.Header+string(bs)); err != nil {
+		return false, err
+	}
+
+	return ok, nil
+}
+
+func (c *command) printResultsInCSV(w io.Writer, rc <-chan *pageResult) (bool, error) {
+	cw := csv.NewWriter(w)
+	ok := true
+
+	if err := cw.Write(csvPageResultHeader); err != nil {
+		return false, err
+	}
+
+	for r := range rc {
+		if err := cw.WriteAll(newCSVPageResult(r)); err != nil {
+			return false, err
+		}
+
+		ok = ok && r.OK()
+	}
+
+	cw.Flush()
+
+	return ok, cw.Error()
+}
+
+func (c *command) printResultsInGitHubWorkflowCommands(w io.Writer, rc <-chan *pageResult) (bool, error) {
+	ok := true
+
+	for r := range rc {
+		if !r.OK() || r.Warned() {
+			if err := c.fprint(w, formatGitHubPageResult(r)); err != nil {
+				return false, err
+			}
+		}
+
+		ok = ok && r.OK()
+	}
+
+	return ok, nil
+}
+
+func (c *command) printResultsInGitLabCodeQuality(w io.Writer, rc <-chan *pageResult) (bool, error) {
+	is := []*gitLabCodeQualityIssue{}
+	ok := true
+
+	for r := range rc {
+		is = append(is, newGitLabCodeQualityIssues(r)...)
+		ok = ok && r.OK()
+	}
+
+	bs, err := json.Marshal(is)
+	if err != nil {
+		return false, err
+	}
+
+	if err := c.fprint(w, string(bs)); err != nil {
+		return false, err
+	}
 
 	return ok, nil
 }
 
+func (c *command) printResultsInTAP(w io.Writer, rc <-chan *pageResult) (bool, error) {
+	if err := c.fprint(w, fmt.Sprintf("TAP version %v", tapVersion)); err != nil {
+		return false, err
+	}
+
+	i := 0
+	ok := true
+
+	for r := range rc {
+		i++
+
+		if err := c.fprint(w, formatTAPPageResult(r, i)); err != nil {
+			return false, err
+		}
+
+		ok = ok && r.OK()
+	}
+
+	return ok, c.fprint(w, fmt.Sprintf("1..%v", i))
+}
+
 func (c *command) print(xs ...any) {
-	if _, err := fmt.Fprintln(c.stdout, strings.TrimSpace(fmt.Sprint(xs...))); err != nil {
+	if err := c.fprint(c.stdout, xs...); err != nil {
 		panic(err)
 	}
 }
 
+func (*command) fprint(w io.Writer, xs ...any) error {
+	_, err := fmt.Fprintln(w, strings.TrimSpace(fmt.Sprint(xs...)))
+	return err
+}
+
 func (c *command) printError(xs ...any) {
 	s := fmt.Sprint(xs...)
 
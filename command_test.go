@@ -5,9 +5,12 @@ import (
 	"errors"
 	"io"
 	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bradleyjkemp/cupaloy"
 	"github.com/stretchr/testify/assert"
@@ -22,6 +25,7 @@ func newTestCommandWithStdout(stdout io.Writer, h func(*url.URL) (*fakeHttpRespo
 		stdout,
 		io.Discard,
 		false,
+		false,
 		newFakeHttpClientFactory(h),
 	)
 }
@@ -31,6 +35,7 @@ func newTestCommandWithStderr(stderr io.Writer, h func(*url.URL) (*fakeHttpRespo
 		io.Discard,
 		stderr,
 		false,
+		false,
 		newFakeHttpClientFactory(h),
 	)
 }
@@ -176,6 +181,7 @@ func TestCommandColorErrorMessage(t *testing.T) {
 		io.Discard,
 		b,
 		true,
+		false,
 		newFakeHttpClientFactory(func(u *url.URL) (*fakeHttpResponse, error) {
 			return nil, errors.New("foo")
 		}),
@@ -301,3 +307,357 @@ func TestCommandFailWithVerboseAndJUnitOptions(t *testing.T) {
 	assert.False(t, ok)
 	cupaloy.SnapshotT(t, b.String())
 }
+
+func TestCommandFailToRunWithCSVOutput(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			if u.String() == "http://foo.com" {
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /></body></html>`,
+				), nil
+			}
+
+			return nil, errors.New("foo")
+		},
+	).Run([]string{"--format", "csv", "http://foo.com"})
+
+	assert.False(t, ok)
+	cupaloy.SnapshotT(t, b.String())
+}
+
+func TestCommandRunWithCSVOutput(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			if u.String() == "http://foo.com" {
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /></body></html>`,
+				), nil
+			}
+
+			return newFakeHttpResponse(200, u.String(), nil, map[string]string{"content-type": "text/plain"}), nil
+		},
+	).Run([]string{"--format", "csv", "http://foo.com"})
+
+	assert.True(t, ok)
+	cupaloy.SnapshotT(t, b.String())
+}
+
+func TestCommandFailToRunWithGitHubOutput(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			if u.String() == "http://foo.com" {
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /></body></html>`,
+				), nil
+			}
+
+			return nil, errors.New("foo")
+		},
+	).Run([]string{"--format", "github", "http://foo.com"})
+
+	assert.False(t, ok)
+	cupaloy.SnapshotT(t, b.String())
+}
+
+func TestCommandRunWithWarningsInGitHubOutput(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			if u.String() == "http://foo.com" {
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /></body></html>`,
+				), nil
+			}
+
+			r := newFakeHtmlResponse(u.String(), "")
+			r.duration = 2 * time.Second
+
+			return r, nil
+		},
+	).Run([]string{"--slow-threshold", "1s", "--fail-on", "warning", "--format", "github", "http://foo.com"})
+
+	assert.False(t, ok)
+	cupaloy.SnapshotT(t, b.String())
+}
+
+func TestCommandFailToRunWithGitLabCodeQualityOutput(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			if u.String() == "http://foo.com" {
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /></body></html>`,
+				), nil
+			}
+
+			return nil, errors.New("foo")
+		},
+	).Run([]string{"--format", "gitlab-codequality", "http://foo.com"})
+
+	assert.False(t, ok)
+	cupaloy.SnapshotT(t, b.String())
+}
+
+func TestCommandFailToRunWithTAPOutput(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			if u.String() == "http://foo.com" {
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /></body></html>`,
+				), nil
+			}
+
+			return nil, errors.New("foo")
+		},
+	).Run([]string{"--format", "tap", "http://foo.com"})
+
+	assert.False(t, ok)
+	cupaloy.SnapshotT(t, b.String())
+}
+
+func TestCommandRunWithOutputFiles(t *testing.T) {
+	b := &bytes.Buffer{}
+	d := t.TempDir()
+	x, j := filepath.Join(d, "report.xml"), filepath.Join(d, "links.json")
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			if u.String() == "http://foo.com" {
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /></body></html>`,
+				), nil
+			}
+
+			return nil, errors.New("foo")
+		},
+	).Run([]string{"--output", "junit=" + x, "--output", "json=" + j, "http://foo.com"})
+
+	assert.False(t, ok)
+	assert.Regexp(t, `http://foo\.com/foo`, b.String())
+
+	bs, err := os.ReadFile(x)
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, string(bs))
+
+	bs, err = os.ReadFile(j)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"url":"http://foo.com","links":[{"url":"http://foo.com/foo","error":"foo","source":{"line":1,"column":13,"element":"a","attribute":"href"}}]}]`+"\n", string(bs))
+}
+
+func TestCommandFailToCreateOutputFile(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStderr(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			return newFakeHtmlResponse("http://foo.com", ""), nil
+		},
+	).Run([]string{"--output", "json=" + filepath.Join(t.TempDir(), "foo", "bar.json"), "http://foo.com"})
+
+	assert.False(t, ok)
+	assert.Greater(t, b.Len(), 0)
+}
+
+func TestCommandFailToWriteOutputFile(t *testing.T) {
+	if _, err := os.Stat("/dev/full"); err != nil {
+		t.Skip("/dev/full not available")
+	}
+
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStderr(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			return newFakeHtmlResponse("http://foo.com", ""), nil
+		},
+	).Run([]string{"--output", "tap=/dev/full", "http://foo.com"})
+
+	assert.False(t, ok)
+	assert.Contains(t, b.String(), "no space left on device")
+}
+
+func TestCommandRunWithSummary(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			if u.String() == "http://foo.com" {
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /></body></html>`,
+				), nil
+			}
+
+			return newFakeHttpResponse(404, u.String(), nil, nil), nil
+		},
+	).Run([]string{"--summary", "http://foo.com"})
+
+	assert.False(t, ok)
+	assert.Regexp(t, `failures: 1\n\t\tstatus_code: 1\n\tstatus codes:\n\t\t404: 1\n`, b.String())
+}
+
+func TestCommandRunWithSummaryInJSON(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			return newFakeHtmlResponse("http://foo.com", ""), nil
+		},
+	).Run([]string{"--summary", "--format", "json", "http://foo.com"})
+
+	assert.True(t, ok)
+	assert.Regexp(t, `^{"pages":\[\],"summary":{"pages":1,`, b.String())
+}
+
+func TestCommandRunWithSummaryInJUnitXML(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			return newFakeHtmlResponse("http://foo.com", ""), nil
+		},
+	).Run([]string{"--summary", "--format", "junit", "http://foo.com"})
+
+	assert.True(t, ok)
+	assert.Regexp(t, `<property name="pages" value="1"></property>`, b.String())
+}
+
+func TestCommandRunGroupingByLinks(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			switch u.String() {
+			case "http://foo.com":
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /><a href="/bar" /></body></html>`,
+				), nil
+			case "http://foo.com/foo":
+				return newFakeHtmlResponse(
+					"http://foo.com/foo",
+					`<html><body><a href="/bar" /></body></html>`,
+				), nil
+			}
+
+			return newFakeHttpResponse(404, u.String(), nil, nil), nil
+		},
+	).Run([]string{"--group-by", "link", "http://foo.com"})
+
+	assert.False(t, ok)
+	cupaloy.SnapshotT(t, b.String())
+}
+
+func TestCommandRunGroupingByLinksInJSON(t *testing.T) {
+	b := &bytes.Buffer{}
+
+	ok := newTestCommandWithStdout(
+		b,
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			switch u.String() {
+			case "http://foo.com":
+				return newFakeHtmlResponse(
+					"http://foo.com",
+					`<html><body><a href="/foo" /><a href="/bar" /></body></html>`,
+				), nil
+			case "http://foo.com/foo":
+				return newFakeHtmlResponse(
+					"http://foo.com/foo",
+					`<html><body><a href="/bar" /></body></html>`,
+				), nil
+			}
+
+			return newFakeHttpResponse(404, u.String(), nil, nil), nil
+		},
+	).Run([]string{"--group-by", "link", "--max-referrers", "1", "--format", "json", "http://foo.com"})
+
+	assert.False(t, ok)
+	cupaloy.SnapshotT(t, b.String())
+}
+
+func TestCommandRunWithWarningsGroupedByLinks(t *testing.T) {
+	for _, f := range []string{"text", "json"} {
+		b := &bytes.Buffer{}
+
+		ok := newTestCommandWithStdout(
+			b,
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				if u.String() == "http://foo.com" {
+					return newFakeHtmlResponse(
+						"http://foo.com",
+						`<html><body><a href="/foo" /></body></html>`,
+					), nil
+				}
+
+				r := newFakeHtmlResponse(u.String(), "")
+				r.duration = 2 * time.Second
+
+				return r, nil
+			},
+		).Run([]string{"--group-by", "link", "--format", f, "--slow-threshold", "1s", "http://foo.com"})
+
+		assert.True(t, ok)
+		assert.Contains(t, b.String(), "slow response (2s)")
+	}
+}
+
+func TestCommandRunWithWarnings(t *testing.T) {
+	for _, c := range []struct {
+		args []string
+		ok   bool
+	}{
+		{[]string{}, true},
+		{[]string{"--fail-on", "error"}, true},
+		{[]string{"--fail-on", "warning"}, false},
+	} {
+		b := &bytes.Buffer{}
+
+		ok := newTestCommandWithStdout(
+			b,
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				if u.String() == "http://foo.com" {
+					return newFakeHtmlResponse(
+						"http://foo.com",
+						`<html><body><a href="/foo" /></body></html>`,
+					), nil
+				}
+
+				r := newFakeHtmlResponse(u.String(), "")
+				r.duration = 2 * time.Second
+
+				return r, nil
+			},
+		).Run(append([]string{"--slow-threshold", "1s", "http://foo.com"}, c.args...))
+
+		assert.Equal(t, c.ok, ok)
+		assert.Contains(t, b.String(), "slow response (2s)")
+	}
+}
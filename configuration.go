@@ -7,4 +7,7 @@ const (
 	agentName   = "muffet"
 	concurrency = 1024
 	tcpTimeout  = 5 * time.Second
+	// spell-checker: disable-next-line
+	progressInterval = 100 * time.Millisecond
+	maxMetaRefreshes = 16
 )
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// connectionTimer records when the first bytes of responses arrive on connections.
+type connectionTimer struct {
+	connections *sync.Map
+}
+
+func newConnectionTimer() *connectionTimer {
+	return &connectionTimer{&sync.Map{}}
+}
+
+// Configure makes a host client dial connections tracked by the timer.
+func (t *connectionTimer) Configure(d fasthttp.DialFunc) func(*fasthttp.HostClient) error {
+	return func(c *fasthttp.HostClient) error {
+		tc := (*tls.Config)(nil)
+
+		if c.IsTLS {
+			h, _, err := net.SplitHostPort(c.Addr)
+			if err != nil {
+				return err
+			}
+
+			tc = &tls.Config{}
+
+			if c.TLSConfig != nil {
+				tc = c.TLSConfig.Clone()
+			}
+
+			if tc.ServerName == "" {
+				tc.ServerName = h
+			}
+		}
+
+		c.Dial = t.Dial(d, tc)
+
+		return nil
+	}
+}
+
+// Dial wraps a dial function so that created connections are tracked by their local addresses.
+// Connections are handshaken with TLS first if a TLS configuration is given so that first bytes
+// of responses are timed after TLS handshakes.
+func (t *connectionTimer) Dial(d fasthttp.DialFunc, tc *tls.Config) fasthttp.DialFunc {
+	return func(address string) (net.Conn, error) {
+		c, err := d(address)
+		if err != nil {
+			return nil, err
+		}
+
+		x := net.Conn(nil)
+		cc := &timedConnection{Conn: c, connections: t.connections}
+
+		if tc != nil {
+			tc := tls.Client(c, tc)
+
+			if err := handshakeTLS(tc); err != nil {
+				c.Close() // nolint:errcheck
+				return nil, err
+			}
+
+			cc.Conn = tc
+			x = &timedTLSConnection{cc}
+		} else {
+			x = cc
+		}
+
+		t.connections.Store(c.LocalAddr().String(), cc)
+
+		return x, nil
+	}
+}
+
+// handshakeTLS runs a TLS handshake with a deadline as fasthttp does for its own TLS connections.
+func handshakeTLS(c *tls.Conn) error {
+	if err := c.SetDeadline(time.Now().Add(tcpTimeout)); err != nil {
+		return err
+	} else if err := c.Handshake(); err != nil {
+		if err, ok := err.(net.Error); ok && err.Timeout() {
+			return fasthttp.ErrTLSHandshakeTimeout
+		}
+
+		return err
+	}
+
+	return c.SetDeadline(time.Time{})
+}
+
+// FirstByteTime returns and forgets a time when the last response arrived on a connection.
+func (t *connectionTimer) FirstByteTime(a net.Addr) (time.Time, bool) {
+	if a == nil {
+		return time.Time{}, false
+	}
+
+	x, ok := t.connections.LoadAndDelete(a.String())
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return x.(*timedConnection).FirstByteTime()
+}
+
+type timedConnection struct {
+	net.Conn
+	connections   *sync.Map
+	mutex         sync.Mutex
+	firstByteTime time.Time
+}
+
+func (c *timedConnection) Read(bs []byte) (int, error) {
+	n, err := c.Conn.Read(bs)
+
+	if n > 0 {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		if c.firstByteTime.IsZero() {
+			c.firstByteTime = time.Now()
+		}
+	}
+
+	return n, err
+}
+
+// Write resets a first byte time as the next bytes read are ones of a new response.
+func (c *timedConnection) Write(bs []byte) (int, error) {
+	c.mutex.Lock()
+	c.firstByteTime = time.Time{}
+	c.mutex.Unlock()
+
+	return c.Conn.Write(bs)
+}
+
+// Close forgets a connection if no response arrived on it as no one asks for its first byte time then.
+func (c *timedConnection) Close() error {
+	if _, ok := c.FirstByteTime(); !ok {
+		c.connections.CompareAndDelete(c.LocalAddr().String(), c)
+	}
+
+	return c.Conn.Close()
+}
+
+// timedTLSConnection is a timed connection regarded as a TLS one by fasthttp.
+// Its handshake is already done on dial.
+type timedTLSConnection struct {
+	*timedConnection
+}
+
+func (c *timedTLSConnection) Handshake() error {
+	return c.Conn.(*tls.Conn).Handshake()
+}
+
+func (c *timedConnection) FirstByteTime() (time.Time, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.firstByteTime, !c.firstByteTime.IsZero()
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionTimerRecordFirstByteTime(t *testing.T) {
+	c, s := net.Pipe()
+	tm := newConnectionTimer()
+
+	x, err := tm.Dial(func(string) (net.Conn, error) { return c, nil }, nil)("foo.com:80")
+	assert.Nil(t, err)
+
+	go func() {
+		bs := make([]byte, 3)
+		_, err := s.Read(bs)
+		assert.Nil(t, err)
+		_, err = s.Write(bs)
+		assert.Nil(t, err)
+	}()
+
+	_, ok := x.(*timedConnection).FirstByteTime()
+	assert.False(t, ok)
+
+	_, err = x.Write([]byte("foo"))
+	assert.Nil(t, err)
+	_, err = x.Read(make([]byte, 3))
+	assert.Nil(t, err)
+
+	_, ok = tm.FirstByteTime(c.LocalAddr())
+	assert.True(t, ok)
+
+	_, ok = tm.FirstByteTime(c.LocalAddr())
+	assert.False(t, ok)
+}
+
+func TestConnectionTimerRecordFirstByteTimeOverTLS(t *testing.T) {
+	ts := httptest.NewTLSServer(nil)
+	defer ts.Close()
+
+	c, s := net.Pipe()
+	tm := newConnectionTimer()
+	ch := make(chan time.Time, 1)
+
+	go func() {
+		s := tls.Server(s, ts.TLS)
+		bs := make([]byte, 3)
+
+		_, err := s.Read(bs)
+		assert.Nil(t, err)
+
+		ch <- time.Now()
+
+		_, err = s.Write(bs)
+		assert.Nil(t, err)
+
+		// Receive a close notification.
+		_, _ = s.Read(bs)
+	}()
+
+	x, err := tm.Dial(
+		func(string) (net.Conn, error) { return c, nil },
+		&tls.Config{InsecureSkipVerify: true},
+	)("foo.com:443")
+	assert.Nil(t, err)
+
+	_, err = x.Write([]byte("foo"))
+	assert.Nil(t, err)
+	_, err = x.Read(make([]byte, 3))
+	assert.Nil(t, err)
+	assert.Nil(t, x.Close())
+
+	ft, ok := tm.FirstByteTime(c.LocalAddr())
+	assert.True(t, ok)
+	assert.False(t, ft.Before(<-ch))
+}
+
+func TestConnectionTimerFailToHandshakeTLS(t *testing.T) {
+	c, s := net.Pipe()
+	tm := newConnectionTimer()
+
+	assert.Nil(t, s.Close())
+
+	_, err := tm.Dial(
+		func(string) (net.Conn, error) { return c, nil },
+		&tls.Config{InsecureSkipVerify: true},
+	)("foo.com:443")
+	assert.NotNil(t, err)
+
+	_, ok := tm.connections.Load(c.LocalAddr().String())
+	assert.False(t, ok)
+}
+
+func TestConnectionTimerForgetConnectionClosedWithoutResponse(t *testing.T) {
+	c, _ := net.Pipe()
+	tm := newConnectionTimer()
+
+	x, err := tm.Dial(func(string) (net.Conn, error) { return c, nil }, nil)("foo.com:80")
+	assert.Nil(t, err)
+
+	_, ok := tm.connections.Load(c.LocalAddr().String())
+	assert.True(t, ok)
+
+	assert.Nil(t, x.Close())
+
+	_, ok = tm.connections.Load(c.LocalAddr().String())
+	assert.False(t, ok)
+}
+
+func TestConnectionTimerFirstByteTimeWithNilAddress(t *testing.T) {
+	_, ok := newConnectionTimer().FirstByteTime(nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+)
+
+type cssLinkFinder struct {
+	linkFilterer  linkFilterer
+	urlNormalizer *urlNormalizer
+}
+
+func newCSSLinkFinder(f linkFilterer, n *urlNormalizer) cssLinkFinder {
+	return cssLinkFinder{f, n}
+}
+
+// Find finds links in CSS resolving them against a base URL.
+func (f cssLinkFinder) Find(s string, base *url.URL) map[string]error {
+	ls := map[string]error{}
+
+	for _, s := range parseCSSURLs(s) {
+		s = strings.TrimSpace(s)
+
+		// Fragment-only URLs refer to elements in documents using stylesheets.
+		if s == "" || strings.HasPrefix(s, "#") {
+			continue
+		}
+
+		u, err := url.Parse(s)
+		if err != nil {
+			ls[s] = err
+			continue
+		}
+
+		u = base.ResolveReference(u)
+
+		if f.linkFilterer.IsValid(u) {
+			ls[u.String()] = nil
+		}
+	}
+
+	f.urlNormalizer.DeduplicateLinks(ls, nil)
+
+	return ls
+}
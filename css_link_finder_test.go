@@ -0,0 +1,55 @@
+package main
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSSLinkFinderFind(t *testing.T) {
+	b, err := url.Parse("http://foo.com/css/style.css")
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		map[string]error{
+			"http://foo.com/css/foo.png": nil,
+			"http://foo.com/bar.woff":    nil,
+			"http://bar.com/baz.css":     nil,
+		},
+		newCSSLinkFinder(newTestLinkFilterer(), nil).Find(
+			`@import "http://bar.com/baz.css";
+			body { background: url(foo.png); }
+			svg { clip-path: url(#foo); }
+			div { background: url(data:image/png;base64,iVBORw0KGgo=); }
+			@font-face { src: url(/bar.woff); }`,
+			b,
+		),
+	)
+}
+
+func TestCSSLinkFinderFindWithInvalidURL(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	ls := newCSSLinkFinder(newTestLinkFilterer(), nil).Find(`body { background: url(":"); }`, b)
+
+	assert.Equal(t, 1, len(ls))
+	assert.NotNil(t, ls[":"])
+}
+
+func TestCSSLinkFinderFindWithFilterer(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		map[string]error{"http://foo.com/bar.png": nil},
+		newCSSLinkFinder(newLinkFilterer([]*regexp.Regexp{regexp.MustCompile("foo.png")}, nil), nil).Find(
+			`body { background: url(foo.png), url(bar.png); }`,
+			b,
+		),
+	)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"net/url"
+)
+
+type cssPage struct {
+	url     *url.URL
+	links   map[string]error
+	sources map[string]*linkSource
+}
+
+func newCSSPage(u *url.URL, links map[string]error) *cssPage {
+	// Resources referred by stylesheets are always loaded as a part of pages.
+	s := &linkSource{Element: "stylesheet", Subresource: true}
+	ss := make(map[string]*linkSource, len(links))
+
+	for l := range links {
+		ss[l] = s
+	}
+
+	return &cssPage{u, links, ss}
+}
+
+func (p *cssPage) URL() *url.URL {
+	return p.url
+}
+
+func (p *cssPage) Fragments() map[string]struct{} {
+	return nil
+}
+
+func (p *cssPage) Text() string {
+	return ""
+}
+
+func (p *cssPage) Links() map[string]error {
+	return p.links
+}
+
+func (p *cssPage) LinkSources() map[string]*linkSource {
+	return p.sources
+}
+
+func (p *cssPage) Warnings() []string {
+	return nil
+}
+
+func (p *cssPage) MetaRefreshURL() *url.URL {
+	return nil
+}
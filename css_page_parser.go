@@ -0,0 +1,23 @@
+package main
+
+import (
+	"net/url"
+)
+
+type cssPageParser struct {
+	linkFinder cssLinkFinder
+}
+
+func newCSSPageParser(f cssLinkFinder) *cssPageParser {
+	return &cssPageParser{f}
+}
+
+func (p *cssPageParser) Parse(u *url.URL, typ string, bs []byte) (page, error) {
+	if typ != "text/css" {
+		return nil, nil
+	}
+
+	u.Fragment = ""
+
+	return newCSSPage(u, p.linkFinder.Find(string(bs), u)), nil
+}
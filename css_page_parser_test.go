@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCSSPageParser() *cssPageParser {
+	return newCSSPageParser(newCSSLinkFinder(newTestLinkFilterer(), nil))
+}
+
+func TestCSSPageParserParse(t *testing.T) {
+	p, err := newTestCSSPageParser().Parse(
+		parseURL(t, "http://foo.com/style.css#foo"),
+		"text/css",
+		[]byte(`body { background: url(foo.png); }`),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "http://foo.com/style.css", p.URL().String())
+	assert.Equal(t, map[string]error{"http://foo.com/foo.png": nil}, p.Links())
+	assert.Equal(
+		t,
+		map[string]*linkSource{"http://foo.com/foo.png": {Element: "stylesheet", Subresource: true}},
+		p.LinkSources(),
+	)
+	assert.Nil(t, p.Fragments())
+}
+
+func TestCSSPageParserIgnoreNonCSS(t *testing.T) {
+	p, err := newTestCSSPageParser().Parse(parseURL(t, "http://foo.com"), "text/html", nil)
+
+	assert.Nil(t, err)
+	assert.Nil(t, p)
+}
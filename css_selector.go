@@ -0,0 +1,311 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yhat/scrape"
+	"golang.org/x/net/html"
+)
+
+// cssSelector is a list of complex selectors in a subset of CSS.
+// It supports type, universal, ID, class, and attribute selectors with descendant and child combinators.
+type cssSelector []*cssComplexSelector
+
+type cssComplexSelector struct {
+	// Compounds are compound selectors from left to right.
+	Compounds []*cssCompoundSelector
+	// Combinators are ' ' or '>' between compound selectors.
+	Combinators []byte
+}
+
+type cssCompoundSelector struct {
+	// Element is a name of elements or empty for any elements.
+	Element    string
+	ID         string
+	Classes    []string
+	Attributes []*cssAttributeSelector
+}
+
+type cssAttributeSelector struct {
+	Name string
+	// Operator is one of "", "=", "~=", "|=", "^=", "$=", and "*=".
+	Operator string
+	Value    string
+}
+
+type cssSelectorParser struct {
+	source string
+	index  int
+}
+
+func parseCSSSelector(s string) (cssSelector, error) {
+	p := &cssSelectorParser{s, 0}
+	ss := cssSelector{}
+
+	for {
+		c, err := p.parseComplexSelector()
+		if err != nil {
+			return nil, err
+		}
+
+		ss = append(ss, c)
+
+		if p.eof() {
+			return ss, nil
+		} else if p.peek() != ',' {
+			return nil, p.error()
+		}
+
+		p.index++
+	}
+}
+
+// Match returns true if an element matches any of complex selectors.
+func (s cssSelector) Match(n *html.Node) bool {
+	for _, c := range s {
+		if c.match(n, len(c.Compounds)-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *cssComplexSelector) match(n *html.Node, i int) bool {
+	if !s.Compounds[i].match(n) {
+		return false
+	} else if i == 0 {
+		return true
+	}
+
+	for n := n.Parent; n != nil && n.Type == html.ElementNode; n = n.Parent {
+		if s.match(n, i-1) {
+			return true
+		} else if s.Combinators[i-1] == '>' {
+			return false
+		}
+	}
+
+	return false
+}
+
+func (s *cssCompoundSelector) match(n *html.Node) bool {
+	if n.Type != html.ElementNode || s.Element != "" && n.Data != s.Element {
+		return false
+	} else if v, ok := htmlAttribute(n, "id"); s.ID != "" && (!ok || v != s.ID) {
+		return false
+	}
+
+	cs := strings.Fields(scrape.Attr(n, "class"))
+
+	for _, c := range s.Classes {
+		if !slices.Contains(cs, c) {
+			return false
+		}
+	}
+
+	for _, a := range s.Attributes {
+		if !a.match(n) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *cssAttributeSelector) match(n *html.Node) bool {
+	v, ok := htmlAttribute(n, s.Name)
+
+	if !ok {
+		return false
+	}
+
+	switch s.Operator {
+	case "=":
+		return v == s.Value
+	case "~=":
+		return slices.Contains(strings.Fields(v), s.Value)
+	case "|=":
+		return v == s.Value || strings.HasPrefix(v, s.Value+"-")
+	case "^=":
+		return s.Value != "" && strings.HasPrefix(v, s.Value)
+	case "$=":
+		return s.Value != "" && strings.HasSuffix(v, s.Value)
+	case "*=":
+		return s.Value != "" && strings.Contains(v, s.Value)
+	}
+
+	return true
+}
+
+func (p *cssSelectorParser) parseComplexSelector() (*cssComplexSelector, error) {
+	p.skipWhitespaces()
+
+	c, err := p.parseCompoundSelector()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &cssComplexSelector{Compounds: []*cssCompoundSelector{c}}
+
+	for {
+		w := p.skipWhitespaces()
+
+		if p.eof() || p.peek() == ',' {
+			return s, nil
+		}
+
+		b := byte(' ')
+
+		if p.peek() == '>' {
+			b = '>'
+			p.index++
+			p.skipWhitespaces()
+		} else if !w {
+			return nil, p.error()
+		}
+
+		c, err := p.parseCompoundSelector()
+		if err != nil {
+			return nil, err
+		}
+
+		s.Compounds = append(s.Compounds, c)
+		s.Combinators = append(s.Combinators, b)
+	}
+}
+
+func (p *cssSelectorParser) parseCompoundSelector() (*cssCompoundSelector, error) {
+	s := &cssCompoundSelector{}
+	i := p.index
+
+	if !p.eof() && p.peek() == '*' {
+		p.index++
+	} else {
+		s.Element = strings.ToLower(p.parseIdentifier())
+	}
+
+	for !p.eof() {
+		switch p.peek() {
+		case '#':
+			p.index++
+			s.ID = p.parseIdentifier()
+
+			if s.ID == "" {
+				return nil, p.error()
+			}
+		case '.':
+			p.index++
+			c := p.parseIdentifier()
+
+			if c == "" {
+				return nil, p.error()
+			}
+
+			s.Classes = append(s.Classes, c)
+		case '[':
+			a, err := p.parseAttributeSelector()
+			if err != nil {
+				return nil, err
+			}
+
+			s.Attributes = append(s.Attributes, a)
+		default:
+			if p.index == i {
+				return nil, p.error()
+			}
+
+			return s, nil
+		}
+	}
+
+	if p.index == i {
+		return nil, p.error()
+	}
+
+	return s, nil
+}
+
+func (p *cssSelectorParser) parseAttributeSelector() (*cssAttributeSelector, error) {
+	p.index++
+	p.skipWhitespaces()
+
+	s := &cssAttributeSelector{Name: strings.ToLower(p.parseIdentifier())}
+
+	if s.Name == "" {
+		return nil, p.error()
+	}
+
+	p.skipWhitespaces()
+
+	for _, o := range []string{"=", "~=", "|=", "^=", "$=", "*="} {
+		if strings.HasPrefix(p.source[p.index:], o) {
+			s.Operator = o
+			p.index += len(o)
+			break
+		}
+	}
+
+	if s.Operator != "" {
+		p.skipWhitespaces()
+
+		if !p.eof() && (p.peek() == '"' || p.peek() == '\'') {
+			v, n := scanCSSString(p.source[p.index:])
+			s.Value = v
+			p.index += n
+		} else if s.Value = p.parseIdentifier(); s.Value == "" {
+			return nil, p.error()
+		}
+
+		p.skipWhitespaces()
+	}
+
+	if p.eof() || p.peek() != ']' {
+		return nil, p.error()
+	}
+
+	p.index++
+
+	return s, nil
+}
+
+func (p *cssSelectorParser) parseIdentifier() string {
+	i := p.index
+
+	for !p.eof() && isCSSNameByte(p.peek()) {
+		p.index++
+	}
+
+	return p.source[i:p.index]
+}
+
+func (p *cssSelectorParser) skipWhitespaces() bool {
+	i := p.index
+	p.index = skipCSSWhitespaces(p.source, p.index)
+	return p.index != i
+}
+
+func (p *cssSelectorParser) peek() byte {
+	return p.source[p.index]
+}
+
+func (p *cssSelectorParser) eof() bool {
+	return p.index >= len(p.source)
+}
+
+func (p *cssSelectorParser) error() error {
+	return fmt.Errorf("invalid CSS selector at %v: %v", p.index, p.source)
+}
+
+// htmlAttribute returns a value of an attribute and true if it exists.
+func htmlAttribute(n *html.Node, k string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == k {
+			return a.Val, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yhat/scrape"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestParseCSSSelector(t *testing.T) {
+	for _, c := range []struct {
+		source   string
+		selector cssSelector
+	}{
+		{"a", cssSelector{{Compounds: []*cssCompoundSelector{{Element: "a"}}}}},
+		{"A", cssSelector{{Compounds: []*cssCompoundSelector{{Element: "a"}}}}},
+		{"*", cssSelector{{Compounds: []*cssCompoundSelector{{}}}}},
+		{"doc-link", cssSelector{{Compounds: []*cssCompoundSelector{{Element: "doc-link"}}}}},
+		{"#foo", cssSelector{{Compounds: []*cssCompoundSelector{{ID: "foo"}}}}},
+		{".foo.bar", cssSelector{{Compounds: []*cssCompoundSelector{{Classes: []string{"foo", "bar"}}}}}},
+		{
+			"img[data-src]",
+			cssSelector{{Compounds: []*cssCompoundSelector{{Element: "img", Attributes: []*cssAttributeSelector{{Name: "data-src"}}}}}},
+		},
+		{
+			`a[ rel ~= "foo bar" ][href^=http]`,
+			cssSelector{{Compounds: []*cssCompoundSelector{{
+				Element: "a",
+				Attributes: []*cssAttributeSelector{
+					{Name: "rel", Operator: "~=", Value: "foo bar"},
+					{Name: "href", Operator: "^=", Value: "http"},
+				},
+			}}}},
+		},
+		{
+			"div p",
+			cssSelector{{Compounds: []*cssCompoundSelector{{Element: "div"}, {Element: "p"}}, Combinators: []byte{' '}}},
+		},
+		{
+			"div>p",
+			cssSelector{{Compounds: []*cssCompoundSelector{{Element: "div"}, {Element: "p"}}, Combinators: []byte{'>'}}},
+		},
+		{
+			" div  >  p ",
+			cssSelector{{Compounds: []*cssCompoundSelector{{Element: "div"}, {Element: "p"}}, Combinators: []byte{'>'}}},
+		},
+		{
+			"a, img",
+			cssSelector{
+				{Compounds: []*cssCompoundSelector{{Element: "a"}}},
+				{Compounds: []*cssCompoundSelector{{Element: "img"}}},
+			},
+		},
+	} {
+		s, err := parseCSSSelector(c.source)
+
+		assert.Nil(t, err, c.source)
+		assert.Equal(t, c.selector, s, c.source)
+	}
+}
+
+func TestParseCSSSelectorError(t *testing.T) {
+	for _, s := range []string{
+		"",
+		" ",
+		"a,",
+		",a",
+		"a >",
+		"> a",
+		"#",
+		".",
+		"a[",
+		"a[]",
+		"a[href",
+		"a[href=]",
+		"a[href==foo]",
+		"a:hover",
+		"a + b",
+	} {
+		_, err := parseCSSSelector(s)
+
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestCSSSelectorMatch(t *testing.T) {
+	for _, c := range []struct {
+		selector string
+		html     string
+		match    bool
+	}{
+		{"a", `<a></a>`, true},
+		{"a", `<b></b>`, false},
+		{"*", `<b></b>`, true},
+		{"#foo", `<p id="foo"></p>`, true},
+		{"#foo", `<p id="bar"></p>`, false},
+		{".foo", `<p class="bar foo"></p>`, true},
+		{".foo.bar", `<p class="foo"></p>`, false},
+		{"[href]", `<a href></a>`, true},
+		{"[href]", `<a></a>`, false},
+		{"[rel=foo]", `<a rel="foo"></a>`, true},
+		{"[rel=foo]", `<a rel="foo bar"></a>`, false},
+		{"[rel~=foo]", `<a rel="bar foo"></a>`, true},
+		{"[lang|=en]", `<p lang="en-US"></p>`, true},
+		{"[lang|=en]", `<p lang="english"></p>`, false},
+		{"[href^=http]", `<a href="https://foo.com"></a>`, true},
+		{"[href$='.pdf']", `<a href="/foo.pdf"></a>`, true},
+		{"[href*=foo]", `<a href="/bar/foo/baz"></a>`, true},
+		{"[href*='']", `<a href="/foo"></a>`, false},
+		{"div p", `<div><section><p></p></section></div>`, true},
+		{"div > p", `<div><section><p></p></section></div>`, false},
+		{"div > section > p", `<div><section><p></p></section></div>`, true},
+		{"div p", `<section><p></p></section>`, false},
+		{".foo > p, b", `<div><p></p></div>`, false},
+		{".foo > p, p", `<div><p></p></div>`, true},
+	} {
+		s, err := parseCSSSelector(c.selector)
+		assert.Nil(t, err)
+
+		n, err := html.Parse(strings.NewReader(htmlWithBody(c.html)))
+		assert.Nil(t, err)
+
+		// Match the innermost element in a body.
+		b, ok := scrape.Find(n, scrape.ByTag(atom.Body))
+		assert.True(t, ok)
+
+		for b.FirstChild != nil {
+			b = b.FirstChild
+		}
+
+		assert.Equal(t, c.match, s.Match(b), c.selector)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parseCSSURLs extracts raw URLs of `url()` functions and `@import` rules in CSS.
+func parseCSSURLs(s string) []string {
+	ss := []string{}
+
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], "/*"):
+			j := strings.Index(s[i+2:], "*/")
+
+			if j < 0 {
+				return ss
+			}
+
+			i += j + 4
+		case s[i] == '\\':
+			i += 2
+		case s[i] == '"' || s[i] == '\'':
+			_, n := scanCSSString(s[i:])
+			i += n
+		case s[i] == '@' && hasCSSKeyword(s[i+1:], "import"):
+			i = skipCSSWhitespaces(s, i+len("@import"))
+
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				t, n := scanCSSString(s[i:])
+				ss = append(ss, t)
+				i += n
+			}
+		case hasCSSKeyword(s[i:], "url(") && (i == 0 || !isCSSNameByte(s[i-1])):
+			t, n := scanCSSURL(s[i+len("url("):])
+			ss = append(ss, t)
+			i += len("url(") + n
+		default:
+			i++
+		}
+	}
+
+	return ss
+}
+
+// scanCSSString scans a quoted string and returns its unescaped value and length.
+func scanCSSString(s string) (string, int) {
+	b := &strings.Builder{}
+
+	for i := 1; i < len(s); {
+		switch s[i] {
+		case s[0]:
+			return b.String(), i + 1
+		case '\n':
+			// Unterminated strings end at newlines.
+			return b.String(), i
+		case '\\':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				i += 2
+				continue
+			}
+
+			r, n := unescapeCSS(s[i+1:])
+			b.WriteString(r)
+			i += n + 1
+		default:
+			b.WriteByte(s[i])
+			i++
+		}
+	}
+
+	return b.String(), len(s)
+}
+
+// scanCSSURL scans arguments of a `url()` function and returns its unescaped value and length.
+func scanCSSURL(s string) (string, int) {
+	b := &strings.Builder{}
+	i := skipCSSWhitespaces(s, 0)
+
+	if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+		t, n := scanCSSString(s[i:])
+		b.WriteString(t)
+		i += n
+	} else {
+		for i < len(s) && s[i] != ')' && !isCSSWhitespace(s[i]) {
+			if s[i] != '\\' {
+				b.WriteByte(s[i])
+				i++
+				continue
+			}
+
+			r, n := unescapeCSS(s[i+1:])
+			b.WriteString(r)
+			i += n + 1
+		}
+	}
+
+	if j := strings.IndexByte(s[i:], ')'); j >= 0 {
+		i += j + 1
+	} else {
+		i = len(s)
+	}
+
+	return b.String(), i
+}
+
+// unescapeCSS unescapes a character after a backslash and returns it and the length consumed.
+func unescapeCSS(s string) (string, int) {
+	i := 0
+
+	for i < len(s) && i < 6 && isHexDigit(s[i]) {
+		i++
+	}
+
+	if i == 0 {
+		if s == "" {
+			return "", 0
+		}
+
+		return s[:1], 1
+	}
+
+	n, err := strconv.ParseUint(s[:i], 16, 32)
+	if err != nil || n == 0 || n > 0x10ffff {
+		n = 0xfffd
+	}
+
+	if i < len(s) && isCSSWhitespace(s[i]) {
+		i++
+	}
+
+	return string(rune(n)), i
+}
+
+func skipCSSWhitespaces(s string, i int) int {
+	for i < len(s) && isCSSWhitespace(s[i]) {
+		i++
+	}
+
+	return i
+}
+
+func hasCSSKeyword(s, k string) bool {
+	return len(s) >= len(k) && strings.EqualFold(s[:len(k)], k)
+}
+
+func isCSSWhitespace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\f'
+}
+
+func isCSSNameByte(b byte) bool {
+	return b == '-' || b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= 0x80
+}
+
+func isHexDigit(b byte) bool {
+	return b >= '0' && b <= '9' || b >= 'a' && b <= 'f' || b >= 'A' && b <= 'F'
+}
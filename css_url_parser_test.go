@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSSURLs(t *testing.T) {
+	for _, c := range []struct {
+		css  string
+		urls []string
+	}{
+		{``, []string{}},
+		{`body { color: red; }`, []string{}},
+		{`body { background: url(foo.png); }`, []string{"foo.png"}},
+		{`body { background: URL( foo.png ); }`, []string{"foo.png"}},
+		{`body { background: url("foo.png"); }`, []string{"foo.png"}},
+		{`body { background: url( 'foo.png' ); }`, []string{"foo.png"}},
+		{`body { background: url("foo bar.png"); }`, []string{"foo bar.png"}},
+		{`body { background: url(foo\).png); }`, []string{"foo).png"}},
+		{`body { background: url("foo\"bar.png"); }`, []string{`foo"bar.png`}},
+		{`body { background: url(\66 oo.png); }`, []string{"foo.png"}},
+		{`body { background: url(foo.png), url(bar.png); }`, []string{"foo.png", "bar.png"}},
+		{`@import "foo.css";`, []string{"foo.css"}},
+		{`@import 'foo.css' screen;`, []string{"foo.css"}},
+		{`@IMPORT url(foo.css);`, []string{"foo.css"}},
+		{`@import url("foo.css") layer(base);`, []string{"foo.css"}},
+		{
+			`@font-face { font-family: foo; src: local(foo), url(foo.woff2) format("woff2"), url(foo.woff); }`,
+			[]string{"foo.woff2", "foo.woff"},
+		},
+		{`/* url(foo.png) */ body { background: url(bar.png); }`, []string{"bar.png"}},
+		{`/* url(foo.png)`, []string{}},
+		{`body::after { content: "url(foo.png)"; }`, []string{}},
+		{`body { background: my-url(foo.png); }`, []string{}},
+		{`body { background: url(foo.png`, []string{"foo.png"}},
+	} {
+		assert.Equal(t, c.urls, parseCSSURLs(c.css), c.css)
+	}
+}
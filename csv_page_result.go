@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var csvPageResultHeader = []string{
+	"page",
+	"url",
+	"status",
+	"error",
+	"content_type",
+	"response_time",
+	"time_to_first_byte",
+	"redirect_url",
+	"warnings",
+	"line",
+	"column",
+	"element",
+	"attribute",
+	"text",
+}
+
+// newCSVPageResult converts a page result into CSV records of all its links.
+func newCSVPageResult(r *pageResult) [][]string {
+	rs := make([][]string, 0, len(r.Warnings)+len(r.SuccessLinkResults)+len(r.WarningLinkResults)+len(r.ErrorLinkResults))
+
+	// Page warnings are recorded in rows without link URLs.
+	for _, w := range r.Warnings {
+		rs = append(rs, append([]string{r.URL, "", "", "", "", "", "", "", w}, newCSVLinkSource(nil)...))
+	}
+
+	for _, l := range r.SuccessLinkResults {
+		rs = append(rs, newCSVSuccessLinkResult(r.URL, l, nil))
+	}
+
+	for _, l := range r.WarningLinkResults {
+		rs = append(rs, newCSVSuccessLinkResult(r.URL, &l.successLinkResult, l.Warnings))
+	}
+
+	for _, l := range r.ErrorLinkResults {
+		s := ""
+
+		if c := l.StatusCode(); c != 0 {
+			s = strconv.Itoa(c)
+		}
+
+		rs = append(
+			rs,
+			append(
+				[]string{r.URL, l.URL, s, l.Error.Error(), "", "", "", "", ""},
+				newCSVLinkSource(l.Source)...,
+			),
+		)
+	}
+
+	return rs
+}
+
+func newCSVSuccessLinkResult(page string, l *successLinkResult, warnings []string) []string {
+	return append(
+		[]string{
+			page,
+			l.URL,
+			strconv.Itoa(l.StatusCode),
+			"",
+			l.ContentType,
+			formatSeconds(l.Duration),
+			formatSeconds(l.TimeToFirstByte),
+			l.RedirectURL,
+			strings.Join(warnings, "; "),
+		},
+		newCSVLinkSource(l.Source)...,
+	)
+}
+
+func newCSVLinkSource(s *linkSource) []string {
+	if s == nil {
+		return []string{"", "", "", "", ""}
+	} else if s.Line == 0 {
+		return []string{"", "", s.Element, s.Attribute, s.Text}
+	}
+
+	return []string{strconv.Itoa(s.Line), strconv.Itoa(s.Column), s.Element, s.Attribute, s.Text}
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bradleyjkemp/cupaloy"
+)
+
+func TestNewErrorCSVPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, newCSVPageResult(
+		&pageResult{
+			URL:                "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{},
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("baz")},
+				{URL: "http://foo.com/baz", Error: newStatusCodeError(404)},
+			},
+		}))
+}
+
+func TestNewSuccessCSVPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, newCSVPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
+		}))
+}
+
+func TestNewDetailedSuccessCSVPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, newCSVPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{
+					URL:             "http://foo.com/foo",
+					StatusCode:      200,
+					ContentType:     "text/html",
+					TimeToFirstByte: 21 * time.Millisecond,
+					Duration:        42 * time.Millisecond,
+					RedirectURL:     "http://foo.com/bar",
+				},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
+		}))
+}
+
+func TestNewWarningCSVPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, newCSVPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200},
+			},
+			WarningLinkResults: []*warningLinkResult{
+				{successLinkResult{URL: "http://foo.com/bar", StatusCode: 200}, []string{"foo", "bar"}},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
+		}))
+}
+
+func TestNewCSVPageResultWithLinkSources(t *testing.T) {
+	cupaloy.SnapshotT(t, newCSVPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200, Source: &linkSource{Element: "img", Attribute: "src"}},
+			},
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("baz"), Source: &linkSource{Line: 42, Column: 3, Element: "a", Attribute: "href", Text: "qux"}},
+			},
+		}))
+}
+
+func TestNewPageWarningCSVPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, newCSVPageResult(
+		&pageResult{URL: "http://foo.com", Warnings: []string{"duplicate id #foo"}},
+	))
+}
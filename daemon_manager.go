@@ -1,21 +1,27 @@
 package main
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 type daemonManager struct {
 	daemons   chan func()
 	waitGroup *sync.WaitGroup
+	count     *atomic.Int64
 }
 
 func newDaemonManager(capacity int) *daemonManager {
-	return &daemonManager{make(chan func(), capacity), &sync.WaitGroup{}}
+	return &daemonManager{make(chan func(), capacity), &sync.WaitGroup{}, &atomic.Int64{}}
 }
 
 func (m daemonManager) Add(f func()) {
 	m.waitGroup.Add(1)
+	m.count.Add(1)
 
 	m.daemons <- func() {
 		f()
+		m.count.Add(-1)
 		m.waitGroup.Done()
 	}
 }
@@ -29,3 +35,8 @@ func (m daemonManager) Run() {
 
 	m.waitGroup.Wait()
 }
+
+// Count returns a number of daemons not finished yet.
+func (m daemonManager) Count() int {
+	return int(m.count.Load())
+}
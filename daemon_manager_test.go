@@ -20,3 +20,14 @@ func TestDaemonsRun(t *testing.T) {
 	assert.Equal(t, 1, x)
 	assert.Zero(t, len(m.daemons))
 }
+
+func TestDaemonManagerCount(t *testing.T) {
+	m := newDaemonManager(42)
+	m.Add(func() {})
+
+	assert.Equal(t, 1, m.Count())
+
+	m.Run()
+
+	assert.Equal(t, 0, m.Count())
+}
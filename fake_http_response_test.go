@@ -1,12 +1,18 @@
 package main
 
-import "strings"
+import (
+	"strings"
+	"time"
+)
 
 type fakeHttpResponse struct {
-	statusCode int
-	location   string
-	body       []byte
-	headers    map[string]string
+	statusCode      int
+	location        string
+	body            []byte
+	headers         map[string]string
+	timeToFirstByte time.Duration
+	duration        time.Duration
+	redirects       []*redirectHop
 }
 
 func newFakeHttpResponse(statusCode int, location string, body []byte, headers map[string]string) *fakeHttpResponse {
@@ -16,7 +22,7 @@ func newFakeHttpResponse(statusCode int, location string, body []byte, headers m
 		hs[strings.ToLower(k)] = v
 	}
 
-	return &fakeHttpResponse{statusCode, location, body, hs}
+	return &fakeHttpResponse{statusCode, location, body, hs, 0, 0, nil}
 }
 
 func newFakeHtmlResponse(location string, body string) *fakeHttpResponse {
@@ -37,6 +43,15 @@ func newFakeXmlResponse(location string, body string) *fakeHttpResponse {
 	)
 }
 
+func newFakeCSSResponse(location string, body string) *fakeHttpResponse {
+	return newFakeHttpResponse(
+		200,
+		location,
+		[]byte(body),
+		map[string]string{"content-type": "text/css"},
+	)
+}
+
 func (r *fakeHttpResponse) URL() string {
 	return r.location
 }
@@ -56,3 +71,15 @@ func (r *fakeHttpResponse) Header(name string) string {
 func (r *fakeHttpResponse) Body() ([]byte, error) {
 	return r.body, nil
 }
+
+func (r *fakeHttpResponse) TimeToFirstByte() time.Duration {
+	return r.timeToFirstByte
+}
+
+func (r *fakeHttpResponse) Duration() time.Duration {
+	return r.duration
+}
+
+func (r *fakeHttpResponse) Redirects() []*redirectHop {
+	return r.redirects
+}
@@ -10,13 +10,19 @@ import (
 )
 
 type fasthttpHttpClient struct {
-	client  *fasthttp.Client
-	timeout time.Duration
-	header  http.Header
+	client          *fasthttp.Client
+	connectionTimer *connectionTimer
+	timeout         time.Duration
+	header          http.Header
 }
 
-func newFasthttpHttpClient(c *fasthttp.Client, timeout time.Duration, header http.Header) httpClient {
-	return &fasthttpHttpClient{c, timeout, header}
+func newFasthttpHttpClient(
+	c *fasthttp.Client,
+	t *connectionTimer,
+	timeout time.Duration,
+	header http.Header,
+) httpClient {
+	return &fasthttpHttpClient{c, t, timeout, header}
 }
 
 func (c *fasthttpHttpClient) Get(u *url.URL, header http.Header) (httpResponse, error) {
@@ -41,12 +47,22 @@ func (c *fasthttpHttpClient) Get(u *url.URL, header http.Header) (httpResponse,
 		req.Header.Add("Accept", "*/*")
 	}
 
+	t := time.Now()
 	err := c.client.DoTimeout(&req, &res, c.timeout)
+	ft, ok := c.connectionTimer.FirstByteTime(res.LocalAddr())
+
 	if err != nil {
 		return nil, err
 	}
 
-	return newFasthttpHttpResponse(req.URI(), &res), nil
+	d := time.Since(t)
+	fd := d
+
+	if ok && ft.After(t) {
+		fd = ft.Sub(t)
+	}
+
+	return newFasthttpHttpResponse(req.URI(), &res, fd, d), nil
 }
 
 func includeHeader(h http.Header, k string) bool {
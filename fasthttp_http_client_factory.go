@@ -40,6 +40,8 @@ func (*fasthttpHttpClientFactory) Create(o httpClientOptions) httpClient {
 		}
 	}
 
+	t := newConnectionTimer()
+
 	return newFasthttpHttpClient(
 		&fasthttp.Client{
 			MaxConnsPerHost: o.MaxConnectionsPerHost,
@@ -47,12 +49,13 @@ func (*fasthttpHttpClientFactory) Create(o httpClientOptions) httpClient {
 			TLSConfig: &tls.Config{
 				InsecureSkipVerify: o.SkipTLSVerification,
 			},
-			Dial:                     d,
+			ConfigureClient:          t.Configure(d),
 			DialDualStack:            true,
 			DisablePathNormalizing:   true,
 			NoDefaultUserAgentHeader: true,
 			MaxResponseBodySize:      o.MaxResponseBodySize,
 		},
+		t,
 		o.Timeout,
 		o.Header,
 	)
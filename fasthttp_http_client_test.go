@@ -0,0 +1,45 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("foo"))
+	})
+}
+
+func testFasthttpHttpClientTimes(t *testing.T, s *httptest.Server) {
+	defer s.Close()
+
+	u, err := url.Parse(s.URL)
+	assert.Nil(t, err)
+
+	r, err := newFasthttpHttpClientFactory().Create(
+		httpClientOptions{
+			Timeout:             time.Second,
+			BufferSize:          4096,
+			MaxResponseBodySize: 4096,
+			SkipTLSVerification: true,
+		},
+	).Get(u, nil)
+	assert.Nil(t, err)
+
+	assert.Greater(t, r.TimeToFirstByte(), time.Duration(0))
+	assert.LessOrEqual(t, r.TimeToFirstByte(), r.Duration())
+}
+
+func TestFasthttpHttpClientGetTimes(t *testing.T) {
+	testFasthttpHttpClientTimes(t, httptest.NewServer(newTestHandler()))
+}
+
+func TestFasthttpHttpClientGetTimesOverTLS(t *testing.T) {
+	testFasthttpHttpClientTimes(t, httptest.NewTLSServer(newTestHandler()))
+}
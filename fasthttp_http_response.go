@@ -1,16 +1,24 @@
 package main
 
 import (
+	"time"
+
 	"github.com/valyala/fasthttp"
 )
 
 type fasthttpHttpResponse struct {
-	url      *fasthttp.URI
-	response *fasthttp.Response
+	url             *fasthttp.URI
+	response        *fasthttp.Response
+	timeToFirstByte time.Duration
+	duration        time.Duration
 }
 
-func newFasthttpHttpResponse(u *fasthttp.URI, r *fasthttp.Response) httpResponse {
-	return fasthttpHttpResponse{u, r}
+func newFasthttpHttpResponse(
+	u *fasthttp.URI,
+	r *fasthttp.Response,
+	timeToFirstByte, duration time.Duration,
+) httpResponse {
+	return fasthttpHttpResponse{u, r, timeToFirstByte, duration}
 }
 
 func (r fasthttpHttpResponse) URL() string {
@@ -37,3 +45,15 @@ func (r fasthttpHttpResponse) Body() ([]byte, error) {
 
 	return r.response.Body(), nil
 }
+
+func (r fasthttpHttpResponse) TimeToFirstByte() time.Duration {
+	return r.timeToFirstByte
+}
+
+func (r fasthttpHttpResponse) Duration() time.Duration {
+	return r.duration
+}
+
+func (r fasthttpHttpResponse) Redirects() []*redirectHop {
+	return nil
+}
@@ -23,7 +23,7 @@ func TestFastHttpResponseDecodeGzipBody(t *testing.T) {
 	r.Header.Add("Content-Encoding", "gzip")
 	r.SetBody(b.Bytes())
 
-	bs, err := newFasthttpHttpResponse(nil, &r).Body()
+	bs, err := newFasthttpHttpResponse(nil, &r, 0, 0).Body()
 
 	assert.Nil(t, err)
 	assert.Equal(t, "foo", string(bs))
@@ -41,7 +41,7 @@ func TestFastHttpResponseDecodeDeflateBody(t *testing.T) {
 	r.Header.Add("Content-Encoding", "deflate")
 	r.SetBody(b.Bytes())
 
-	bs, err := newFasthttpHttpResponse(nil, &r).Body()
+	bs, err := newFasthttpHttpResponse(nil, &r, 0, 0).Body()
 
 	assert.Nil(t, err)
 	assert.Equal(t, "foo", string(bs))
@@ -59,7 +59,7 @@ func TestFastHttpResponseDecodeBrotliBody(t *testing.T) {
 	r.Header.Add("Content-Encoding", "br")
 	r.SetBody(b.Bytes())
 
-	bs, err := newFasthttpHttpResponse(nil, &r).Body()
+	bs, err := newFasthttpHttpResponse(nil, &r, 0, 0).Body()
 
 	assert.Nil(t, err)
 	assert.Equal(t, "foo", string(bs))
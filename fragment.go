@@ -0,0 +1,32 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// findFragment finds an element indicated by a percent-encoded fragment in a page.
+// https://html.spec.whatwg.org/multipage/browsing-the-web.html#the-indicated-part-of-the-document
+func findFragment(p page, s string) (bool, error) {
+	if s == "" {
+		return true, nil
+	} else if _, ok := p.Fragments()[normalizeFragment(s)]; ok {
+		return true, nil
+	}
+
+	t, err := url.PathUnescape(s)
+	if err != nil {
+		return false, err
+	} else if _, ok := p.Fragments()[normalizeFragment(t)]; ok {
+		return true, nil
+	}
+
+	return strings.EqualFold(t, "top"), nil
+}
+
+// normalizeFragment normalizes a fragment identifier in Unicode.
+func normalizeFragment(s string) string {
+	return norm.NFC.String(s)
+}
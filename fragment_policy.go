@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// fragmentPolicy decides if fragments of links matched with a URL pattern are checked.
+type fragmentPolicy struct {
+	URLPattern *regexp.Regexp
+	Ignored    bool
+	// IgnoredPattern matches fragments ignored. If it is nil, all fragments are checked or ignored.
+	IgnoredPattern *regexp.Regexp
+}
+
+// parseFragmentPolicy parses a fragment policy in a format of "<url-pattern> <policy> [<fragment-pattern>]"
+// where a policy is "check", "ignore", or "ignore-matching".
+func parseFragmentPolicy(s string) (*fragmentPolicy, error) {
+	ss := strings.Fields(s)
+
+	if len(ss) < 2 {
+		return nil, errors.New("invalid fragment policy format")
+	}
+
+	u, err := regexp.Compile(ss[0])
+	if err != nil {
+		return nil, err
+	}
+
+	switch ss[1] {
+	case "check":
+		if len(ss) == 2 {
+			return &fragmentPolicy{URLPattern: u}, nil
+		}
+	case "ignore":
+		if len(ss) == 2 {
+			return &fragmentPolicy{URLPattern: u, Ignored: true}, nil
+		}
+	case "ignore-matching":
+		if len(ss) == 3 {
+			r, err := regexp.Compile(ss[2])
+			if err != nil {
+				return nil, err
+			}
+
+			return &fragmentPolicy{URLPattern: u, IgnoredPattern: r}, nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid fragment policy: %v", ss[1])
+	}
+
+	return nil, errors.New("invalid fragment policy format")
+}
+
+// Ignores returns true if a percent-encoded fragment is ignored.
+func (p *fragmentPolicy) Ignores(fr string) bool {
+	if p.IgnoredPattern == nil {
+		return p.Ignored
+	}
+
+	if s, err := url.PathUnescape(fr); err == nil {
+		fr = s
+	}
+
+	return p.IgnoredPattern.MatchString(fr)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFragmentPolicy(t *testing.T) {
+	for _, c := range []struct {
+		policy   string
+		fragment string
+		ignored  bool
+	}{
+		{"foo check", "bar", false},
+		{"foo ignore", "bar", true},
+		{"foo ignore-matching ^/", "/bar", true},
+		{"foo ignore-matching ^/", "bar", false},
+		{"foo ignore-matching ^/bar$", "%2Fbar", true},
+		{"  foo   ignore  ", "bar", true},
+	} {
+		p, err := parseFragmentPolicy(c.policy)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", p.URLPattern.String())
+		assert.Equal(t, c.ignored, p.Ignores(c.fragment), c)
+	}
+}
+
+func TestParseFragmentPolicyError(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"foo",
+		"foo bar",
+		"( check",
+		"foo check bar",
+		"foo ignore bar",
+		"foo ignore-matching",
+		"foo ignore-matching (",
+	} {
+		_, err := parseFragmentPolicy(s)
+
+		assert.NotNil(t, err, s)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFragment(t *testing.T) {
+	p := newTestPage(t, map[string]struct{}{"foo": {}, "a b": {}, "é": {}, "%41": {}}, nil)
+
+	for _, c := range []struct {
+		fragment string
+		found    bool
+	}{
+		{"", true},
+		{"foo", true},
+		{"bar", false},
+		{"a%20b", true},
+		{"%C3%A9", true},
+		{"e%CC%81", true},
+		{"%41", true},
+		{"top", true},
+		{"TOP", true},
+		{"Foo", false},
+	} {
+		ok, err := findFragment(p, c.fragment)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.found, ok, c.fragment)
+	}
+}
+
+func TestFindFragmentWithInvalidEncoding(t *testing.T) {
+	_, err := findFragment(newTestPage(t, nil, nil), "%")
+
+	assert.NotNil(t, err)
+}
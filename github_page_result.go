@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	gitHubMessageEscaper  = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	gitHubPropertyEscaper = strings.NewReplacer(
+		"%", "%25",
+		"\r", "%0D",
+		"\n", "%0A",
+		":", "%3A",
+		",", "%2C",
+	)
+)
+
+// formatGitHubPageResult formats a page result as GitHub Actions workflow commands.
+func formatGitHubPageResult(r *pageResult) string {
+	ss := make([]string, 0, len(r.Warnings)+len(r.WarningLinkResults)+len(r.ErrorLinkResults))
+
+	for _, w := range r.Warnings {
+		ss = append(
+			ss,
+			fmt.Sprintf(
+				"::warning title=%v::%v",
+				gitHubPropertyEscaper.Replace("Page warning in "+r.URL),
+				gitHubMessageEscaper.Replace(w),
+			),
+		)
+	}
+
+	for _, l := range r.WarningLinkResults {
+		for _, w := range l.Warnings {
+			ss = append(
+				ss,
+				fmt.Sprintf(
+					"::warning title=%v::%v",
+					gitHubPropertyEscaper.Replace("Link warning in "+r.URL),
+					gitHubMessageEscaper.Replace(w+"\t"+l.URL+formatLinkSource(l.Source)),
+				),
+			)
+		}
+	}
+
+	for _, l := range r.ErrorLinkResults {
+		ss = append(
+			ss,
+			fmt.Sprintf(
+				"::error title=%v::%v",
+				gitHubPropertyEscaper.Replace("Broken link in "+r.URL),
+				gitHubMessageEscaper.Replace(l.Error.Error()+"\t"+l.URL+formatLinkSource(l.Source)),
+			),
+		)
+	}
+
+	return strings.Join(ss, "\n")
+}
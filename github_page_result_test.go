@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatGitHubPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, formatGitHubPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200},
+			},
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("baz")},
+			},
+		}))
+}
+
+func TestFormatGitHubPageResultEscapingSpecialCharacters(t *testing.T) {
+	assert.Equal(
+		t,
+		"::error title=Broken link in http%3A//foo.com/?a=1%2C2::100%25%0Abar\thttp://foo.com/bar",
+		formatGitHubPageResult(
+			&pageResult{
+				URL: "http://foo.com/?a=1,2",
+				ErrorLinkResults: []*errorLinkResult{
+					{URL: "http://foo.com/bar", Error: errors.New("100%\nbar")},
+				},
+			}),
+	)
+}
+
+func TestFormatWarningGitHubPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, formatGitHubPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			WarningLinkResults: []*warningLinkResult{
+				{successLinkResult{URL: "http://foo.com/foo", StatusCode: 200}, []string{"foo", "bar"}},
+			},
+		}))
+}
+
+func TestFormatPageWarningGitHubPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, formatGitHubPageResult(
+		&pageResult{URL: "http://foo.com", Warnings: []string{"duplicate id #foo"}},
+	))
+}
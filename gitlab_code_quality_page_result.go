@@ -0,0 +1,94 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+type gitLabCodeQualityIssue struct {
+	Description string                     `json:"description"`
+	CheckName   string                     `json:"check_name"`
+	Fingerprint string                     `json:"fingerprint"`
+	Severity    string                     `json:"severity"`
+	Location    *gitLabCodeQualityLocation `json:"location"`
+}
+
+type gitLabCodeQualityLocation struct {
+	Path  string                  `json:"path"`
+	Lines *gitLabCodeQualityLines `json:"lines"`
+}
+
+type gitLabCodeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+func newGitLabCodeQualityIssues(r *pageResult) []*gitLabCodeQualityIssue {
+	is := make([]*gitLabCodeQualityIssue, 0, len(r.Warnings)+len(r.WarningLinkResults)+len(r.ErrorLinkResults))
+
+	for _, w := range r.Warnings {
+		is = append(
+			is,
+			&gitLabCodeQualityIssue{
+				Description: w,
+				CheckName:   "page-warning",
+				Fingerprint: gitLabCodeQualityFingerprint("page-warning", r.URL, w),
+				Severity:    "minor",
+				Location: &gitLabCodeQualityLocation{
+					Path:  r.URL,
+					Lines: &gitLabCodeQualityLines{Begin: 1},
+				},
+			},
+		)
+	}
+
+	for _, l := range r.WarningLinkResults {
+		is = append(
+			is,
+			newGitLabCodeQualityIssue(
+				"link-warning",
+				"minor",
+				r.URL,
+				l.URL,
+				strings.Join(l.Warnings, "; "),
+				l.Source,
+			),
+		)
+	}
+
+	for _, l := range r.ErrorLinkResults {
+		is = append(
+			is,
+			newGitLabCodeQualityIssue("broken-link", "major", r.URL, l.URL, l.Error.Error(), l.Source),
+		)
+	}
+
+	return is
+}
+
+func newGitLabCodeQualityIssue(check, severity, page, link, message string, s *linkSource) *gitLabCodeQualityIssue {
+	d := fmt.Sprintf("%v (%v)", link, message)
+
+	if s != nil {
+		d += fmt.Sprintf(" at %v", s)
+	}
+
+	return &gitLabCodeQualityIssue{
+		Description: d,
+		CheckName:   check,
+		Fingerprint: gitLabCodeQualityFingerprint(check, page, link),
+		Severity:    severity,
+		Location: &gitLabCodeQualityLocation{
+			Path:  page,
+			Lines: &gitLabCodeQualityLines{Begin: max(linkSourceLine(s), 1)},
+		},
+	}
+}
+
+// gitLabCodeQualityFingerprint calculates a fingerprint from a check name and a pair of page and link URLs.
+// It does not include messages so that the same issues are tracked across runs.
+func gitLabCodeQualityFingerprint(check, page, link string) string {
+	h := sha256.Sum256([]byte(check + "\n" + page + "\n" + link))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalGitLabCodeQualityIssues(t *testing.T) {
+	bs, err := json.Marshal(newGitLabCodeQualityIssues(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200},
+			},
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("baz")},
+			},
+		}))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestGitLabCodeQualityFingerprintIgnoreErrors(t *testing.T) {
+	is := newGitLabCodeQualityIssues(
+		&pageResult{
+			URL: "http://foo.com",
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("404")},
+			},
+		})
+	js := newGitLabCodeQualityIssues(
+		&pageResult{
+			URL: "http://foo.com",
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("timeout")},
+			},
+		})
+
+	assert.Equal(t, is[0].Fingerprint, js[0].Fingerprint)
+}
+
+func TestGitLabCodeQualityFingerprintDistinguishPages(t *testing.T) {
+	assert.NotEqual(
+		t,
+		gitLabCodeQualityFingerprint("broken-link", "http://foo.com", "http://foo.com/bar"),
+		gitLabCodeQualityFingerprint("broken-link", "http://foo.com/foo", "http://foo.com/bar"),
+	)
+}
+
+func TestGitLabCodeQualityFingerprintDistinguishChecks(t *testing.T) {
+	assert.NotEqual(
+		t,
+		gitLabCodeQualityFingerprint("broken-link", "http://foo.com", "http://foo.com/bar"),
+		gitLabCodeQualityFingerprint("link-warning", "http://foo.com", "http://foo.com/bar"),
+	)
+}
+
+func TestMarshalGitLabCodeQualityWarningIssues(t *testing.T) {
+	bs, err := json.Marshal(newGitLabCodeQualityIssues(
+		&pageResult{
+			URL: "http://foo.com",
+			WarningLinkResults: []*warningLinkResult{
+				{successLinkResult{URL: "http://foo.com/foo", StatusCode: 200}, []string{"foo", "bar"}},
+			},
+		}))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestGitLabCodeQualityIssuesWithLinkSource(t *testing.T) {
+	is := newGitLabCodeQualityIssues(
+		&pageResult{
+			URL: "http://foo.com",
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("404"), Source: &linkSource{Line: 42, Column: 3, Element: "a", Attribute: "href"}},
+			},
+		})
+
+	assert.Equal(t, 42, is[0].Location.Lines.Begin)
+}
+
+func TestMarshalGitLabCodeQualityPageWarningIssues(t *testing.T) {
+	bs, err := json.Marshal(newGitLabCodeQualityIssues(
+		&pageResult{URL: "http://foo.com", Warnings: []string{"duplicate id #foo"}},
+	))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
@@ -0,0 +1,9 @@
+package main
+
+import "net/url"
+
+// hreflangAlternate is a `<link rel="alternate" hreflang="...">` element of a page.
+type hreflangAlternate struct {
+	Language string
+	URL      *url.URL
+}
@@ -5,13 +5,29 @@ import (
 )
 
 type htmlPage struct {
-	url       *url.URL
-	fragments map[string]struct{}
-	links     map[string]error
+	url        *url.URL
+	fragments  map[string]struct{}
+	text       string
+	links      map[string]error
+	sources    map[string]*linkSource
+	warnings   []string
+	refresh    *url.URL
+	canonical  *url.URL
+	alternates []*hreflangAlternate
 }
 
-func newHtmlPage(u *url.URL, fragments map[string]struct{}, links map[string]error) *htmlPage {
-	return &htmlPage{u, fragments, links}
+func newHtmlPage(
+	u *url.URL,
+	fragments map[string]struct{},
+	text string,
+	links map[string]error,
+	sources map[string]*linkSource,
+	warnings []string,
+	refresh *url.URL,
+	canonical *url.URL,
+	alternates []*hreflangAlternate,
+) *htmlPage {
+	return &htmlPage{u, fragments, text, links, sources, warnings, refresh, canonical, alternates}
 }
 
 func (p *htmlPage) URL() *url.URL {
@@ -22,6 +38,31 @@ func (p *htmlPage) Fragments() map[string]struct{} {
 	return p.fragments
 }
 
+func (p *htmlPage) Text() string {
+	return p.text
+}
+
 func (p *htmlPage) Links() map[string]error {
 	return p.links
 }
+
+func (p *htmlPage) LinkSources() map[string]*linkSource {
+	return p.sources
+}
+
+func (p *htmlPage) Warnings() []string {
+	return p.warnings
+}
+
+func (p *htmlPage) MetaRefreshURL() *url.URL {
+	return p.refresh
+}
+
+// CanonicalURL returns a URL of a canonical link or nil if there is none.
+func (p *htmlPage) CanonicalURL() *url.URL {
+	return p.canonical
+}
+
+func (p *htmlPage) HreflangAlternates() []*hreflangAlternate {
+	return p.alternates
+}
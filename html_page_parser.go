@@ -2,7 +2,10 @@ package main
 
 import (
 	"bytes"
+	"fmt"
 	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/yhat/scrape"
 	"golang.org/x/net/html"
@@ -30,14 +33,24 @@ func (p htmlPageParser) Parse(u *url.URL, typ string, body []byte) (page, error)
 	u.Fragment = ""
 
 	frs := map[string]struct{}{}
+	ids := map[string]int{}
+	ws := []string(nil)
 
 	scrape.FindAllNested(n, func(n *html.Node) bool {
-		for _, a := range []string{"id", "name"} {
-			if s := scrape.Attr(n, a); s != "" {
-				frs[s] = struct{}{}
+		if s := scrape.Attr(n, "id"); s != "" {
+			s = normalizeFragment(s)
+			frs[s] = struct{}{}
+
+			if ids[s]++; ids[s] == 2 {
+				ws = append(ws, fmt.Sprintf("duplicate id #%v", s))
 			}
 		}
 
+		// Only anchors can be targeted by names.
+		if s := scrape.Attr(n, "name"); s != "" && n.DataAtom == atom.A {
+			frs[normalizeFragment(s)] = struct{}{}
+		}
+
 		return false
 	})
 
@@ -54,5 +67,75 @@ func (p htmlPageParser) Parse(u *url.URL, typ string, body []byte) (page, error)
 		base = base.ResolveReference(u)
 	}
 
-	return newHtmlPage(u, frs, p.linkFinder.Find(n, base)), nil
+	ls, ss := p.linkFinder.Find(n, base, newHtmlSourceMap(body))
+
+	return newHtmlPage(
+		u,
+		frs,
+		normalizeText(renderedText(n)),
+		ls,
+		ss,
+		ws,
+		findMetaRefreshURL(n, base),
+		findCanonicalURL(n, base),
+		findHreflangAlternates(n, base),
+	), nil
+}
+
+func findMetaRefreshURL(n *html.Node, base *url.URL) *url.URL {
+	for _, n := range scrape.FindAll(n, func(n *html.Node) bool {
+		return n.DataAtom == atom.Meta && isMetaRefresh(scrape.Attr(n, "http-equiv"))
+	}) {
+		if s, ok := parseMetaRefresh(scrape.Attr(n, "content")); ok {
+			if u, err := url.Parse(s); err == nil {
+				return base.ResolveReference(u)
+			}
+		}
+	}
+
+	return nil
+}
+
+func findCanonicalURL(n *html.Node, base *url.URL) *url.URL {
+	for _, n := range findRelatedLinks(n, "canonical") {
+		if u := resolveRelatedLink(n, base); u != nil {
+			return u
+		}
+	}
+
+	return nil
+}
+
+func findHreflangAlternates(n *html.Node, base *url.URL) []*hreflangAlternate {
+	as := []*hreflangAlternate(nil)
+
+	for _, n := range findRelatedLinks(n, "alternate") {
+		if l := strings.TrimSpace(scrape.Attr(n, "hreflang")); l == "" {
+			continue
+		} else if u := resolveRelatedLink(n, base); u != nil {
+			as = append(as, &hreflangAlternate{l, u})
+		}
+	}
+
+	return as
+}
+
+func findRelatedLinks(n *html.Node, rel string) []*html.Node {
+	return scrape.FindAll(n, func(n *html.Node) bool {
+		return n.DataAtom == atom.Link &&
+			slices.Contains(strings.Fields(strings.ToLower(scrape.Attr(n, "rel"))), rel)
+	})
+}
+
+// resolveRelatedLink resolves a URL of a link element without its fragment.
+func resolveRelatedLink(n *html.Node, base *url.URL) *url.URL {
+	u, err := url.Parse(strings.TrimSpace(scrape.Attr(n, "href")))
+	if err != nil {
+		return nil
+	}
+
+	u = base.ResolveReference(u)
+	u.Fragment = ""
+
+	return u
 }
@@ -113,17 +113,27 @@ func TestHtmlPageParserParseName(t *testing.T) {
 	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
 		parseURL(t, "http://foo.com"),
 		HTML_MIME_TYPE,
-		[]byte(`<p name="foo" />`),
+		[]byte(`<a name="foo" />`),
 	)
 	assert.Nil(t, err)
 	assert.Equal(t, map[string]struct{}{"foo": {}}, p.Fragments())
 }
 
+func TestHtmlPageParserIgnoreNameOfNonAnchor(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com"),
+		HTML_MIME_TYPE,
+		[]byte(`<input name="foo" />`),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]struct{}{}, p.Fragments())
+}
+
 func TestHtmlPageParserParseIDAndName(t *testing.T) {
 	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
 		parseURL(t, "http://foo.com"),
 		HTML_MIME_TYPE,
-		[]byte(`<p id="foo" name="bar" />`),
+		[]byte(`<a id="foo" name="bar" />`),
 	)
 	assert.Nil(t, err)
 	assert.Equal(t, map[string]struct{}{"foo": {}, "bar": {}}, p.Fragments())
@@ -152,3 +162,110 @@ func TestHtmlPageParserParseLinks(t *testing.T) {
 		assert.True(t, ok)
 	}
 }
+
+func TestHtmlPageParserParseLinkSources(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com"),
+		HTML_MIME_TYPE,
+		[]byte("<p>foo</p>\n  <a href=\"foo\">bar</a>"),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, &linkSource{Line: 2, Column: 3, Element: "a", Attribute: "href", Text: "bar"}, p.LinkSources()["http://foo.com/foo"])
+}
+
+func TestHtmlPageParserParseText(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com"),
+		HTML_MIME_TYPE,
+		[]byte("<title>Foo</title><p>Hello,\n  <b>World</b>!</p><p>bar</p>"),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "hello, world! bar", p.Text())
+}
+
+func TestHtmlPageParserNormalizeIDs(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com"),
+		HTML_MIME_TYPE,
+		[]byte("<p id=\"e\u0301\" />"),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]struct{}{"\u00e9": {}}, p.Fragments())
+}
+
+func TestHtmlPageParserWarnDuplicateIDs(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com"),
+		HTML_MIME_TYPE,
+		[]byte(`<p id="foo" /><p id="foo" /><p id="foo" /><p id="bar" /><a name="bar" />`),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"duplicate id #foo"}, p.Warnings())
+}
+
+func TestHtmlPageParserParseMetaRefresh(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com/foo/"),
+		HTML_MIME_TYPE,
+		[]byte(`<head><base href="/bar/" /><meta http-equiv="refresh" content="0; url=baz" /></head>`),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "http://foo.com/bar/baz", p.MetaRefreshURL().String())
+	assert.Contains(t, p.Links(), "http://foo.com/bar/baz")
+}
+
+func TestHtmlPageParserParseNoMetaRefresh(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com"),
+		HTML_MIME_TYPE,
+		[]byte(`<meta http-equiv="refresh" content="60" />`),
+	)
+
+	assert.Nil(t, err)
+	assert.Nil(t, p.MetaRefreshURL())
+}
+
+func TestHtmlPageParserParseCanonicalURL(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com/foo"),
+		HTML_MIME_TYPE,
+		[]byte(`<link rel="Canonical" href="/bar#baz" /><link rel="canonical" href="/qux" />`),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "http://foo.com/bar", p.(*htmlPage).CanonicalURL().String())
+}
+
+func TestHtmlPageParserParseHreflangAlternates(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com/foo"),
+		HTML_MIME_TYPE,
+		[]byte(`
+			<link rel="alternate" hreflang="en" href="/en" />
+			<link rel="alternate" hreflang="x-default" href="http://bar.com/#foo" />
+			<link rel="alternate" type="application/rss+xml" href="/feed" />
+			<link rel="stylesheet" hreflang="ja" href="/ja.css" />
+		`),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]*hreflangAlternate{
+			{"en", parseURL(t, "http://foo.com/en")},
+			{"x-default", parseURL(t, "http://bar.com/")},
+		},
+		p.(*htmlPage).HreflangAlternates(),
+	)
+}
+
+func TestHtmlPageParserParseNoCanonicalURL(t *testing.T) {
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(parseURL(t, "http://foo.com"), HTML_MIME_TYPE, nil)
+
+	assert.Nil(t, err)
+	assert.Nil(t, p.(*htmlPage).CanonicalURL())
+	assert.Nil(t, p.(*htmlPage).HreflangAlternates())
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+type htmlSourceKey struct {
+	Element   string
+	Attribute string
+	Value     string
+}
+
+type htmlSourcePosition struct {
+	Line   int
+	Column int
+}
+
+// htmlSourceMap maps attributes of elements to their first positions in HTML source.
+// Contents of style elements are also mapped with empty attribute names.
+type htmlSourceMap map[htmlSourceKey]htmlSourcePosition
+
+func newHtmlSourceMap(body []byte) htmlSourceMap {
+	m := htmlSourceMap{}
+	t := html.NewTokenizer(bytes.NewReader(body))
+	p := htmlSourcePosition{1, 1}
+	e := ""
+
+	for {
+		tt := t.Next()
+
+		if tt == html.ErrorToken {
+			return m
+		}
+
+		q := p
+		p = advanceHtmlSourcePosition(p, t.Raw())
+
+		if tt == html.TextToken && e == "style" {
+			k := htmlSourceKey{e, "", string(t.Text())}
+
+			if _, ok := m[k]; !ok {
+				m[k] = q
+			}
+		}
+
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			e = ""
+			continue
+		}
+
+		tk := t.Token()
+		e = tk.Data
+
+		for _, a := range tk.Attr {
+			k := htmlSourceKey{tk.Data, a.Key, a.Val}
+
+			if _, ok := m[k]; !ok {
+				m[k] = q
+			}
+		}
+	}
+}
+
+// Find finds a position of an element's attribute.
+func (m htmlSourceMap) Find(element, attribute, value string) (htmlSourcePosition, bool) {
+	p, ok := m[htmlSourceKey{element, attribute, value}]
+	return p, ok
+}
+
+func advanceHtmlSourcePosition(p htmlSourcePosition, bs []byte) htmlSourcePosition {
+	for len(bs) > 0 {
+		r, n := utf8.DecodeRune(bs)
+		bs = bs[n:]
+
+		if r == '\n' {
+			p.Line++
+			p.Column = 1
+		} else {
+			p.Column++
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHtmlSourceMapFind(t *testing.T) {
+	m := newHtmlSourceMap([]byte("<html>\n  <body>\n    <a href=\"/foo\">foo</a><img src=\"/bar.png\" />\n  </body>\n</html>"))
+
+	p, ok := m.Find("a", "href", "/foo")
+	assert.True(t, ok)
+	assert.Equal(t, htmlSourcePosition{3, 5}, p)
+
+	p, ok = m.Find("img", "src", "/bar.png")
+	assert.True(t, ok)
+	assert.Equal(t, htmlSourcePosition{3, 27}, p)
+}
+
+func TestHtmlSourceMapFindFirstPosition(t *testing.T) {
+	p, ok := newHtmlSourceMap([]byte("<a href=\"/foo\"></a>\n<a href=\"/foo\"></a>")).Find("a", "href", "/foo")
+
+	assert.True(t, ok)
+	assert.Equal(t, htmlSourcePosition{1, 1}, p)
+}
+
+func TestHtmlSourceMapFindWithMultiByteCharacters(t *testing.T) {
+	p, ok := newHtmlSourceMap([]byte("<p>日本語</p><a href=\"/foo\"></a>")).Find("a", "href", "/foo")
+
+	assert.True(t, ok)
+	assert.Equal(t, htmlSourcePosition{1, 11}, p)
+}
+
+func TestHtmlSourceMapFindUnescapedValue(t *testing.T) {
+	_, ok := newHtmlSourceMap([]byte(`<a href="/foo?a=1&amp;b=2"></a>`)).Find("a", "href", "/foo?a=1&b=2")
+
+	assert.True(t, ok)
+}
+
+func TestHtmlSourceMapFindMissingAttribute(t *testing.T) {
+	_, ok := newHtmlSourceMap([]byte(`<a href="/foo"></a>`)).Find("a", "href", "/bar")
+
+	assert.False(t, ok)
+}
+
+func TestHtmlSourceMapFindAfterRawText(t *testing.T) {
+	p, ok := newHtmlSourceMap(
+		[]byte("<script>\nconst x = '<a href=\"/foo\">';\n</script>\n<a href=\"/foo\"></a>"),
+	).Find("a", "href", "/foo")
+
+	assert.True(t, ok)
+	assert.Equal(t, htmlSourcePosition{4, 1}, p)
+}
+
+func TestHtmlSourceMapFindStyleElement(t *testing.T) {
+	s := "body { background: url(/foo.png); }"
+	p, ok := newHtmlSourceMap([]byte("<head>\n  <style>"+s+"</style>\n</head>")).Find("style", "", s)
+
+	assert.True(t, ok)
+	assert.Equal(t, htmlSourcePosition{2, 10}, p)
+}
@@ -1,8 +1,16 @@
 package main
 
+import "time"
+
 type httpResponse interface {
 	URL() string
 	StatusCode() int
 	Header(string) string
 	Body() ([]byte, error)
+	// TimeToFirstByte returns a duration until the first byte of a response arrives.
+	TimeToFirstByte() time.Duration
+	// Duration returns a duration until a whole response arrives.
+	Duration() time.Duration
+	// Redirects returns redirections followed before a response.
+	Redirects() []*redirectHop
 }
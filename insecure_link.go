@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"net/url"
+)
+
+var errMixedContent = errors.New("insecure subresource on HTTPS page")
+
+// isInsecureLink returns true if a link is HTTP on an HTTPS page.
+func isInsecureLink(page *url.URL, u string) bool {
+	v, err := url.Parse(u)
+
+	return err == nil && page.Scheme == "https" && v.Scheme == "http"
+}
+
+func upgradeToHTTPS(u string) (string, error) {
+	v, err := url.Parse(u)
+	if err != nil {
+		return "", err
+	}
+
+	v.Scheme = "https"
+
+	if v.Port() == "80" {
+		v.Host = v.Hostname()
+	}
+
+	return v.String(), nil
+}
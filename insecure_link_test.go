@@ -0,0 +1,38 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsInsecureLink(t *testing.T) {
+	for _, c := range []struct {
+		page, link string
+		insecure   bool
+	}{
+		{"https://foo.com", "http://foo.com/foo", true},
+		{"https://foo.com", "https://foo.com/foo", false},
+		{"http://foo.com", "http://foo.com/foo", false},
+		{"https://foo.com", "mailto:foo@foo.com", false},
+	} {
+		u, err := url.Parse(c.page)
+		assert.Nil(t, err)
+
+		assert.Equal(t, c.insecure, isInsecureLink(u, c.link))
+	}
+}
+
+func TestUpgradeToHTTPS(t *testing.T) {
+	for _, ss := range [][2]string{
+		{"http://foo.com/foo", "https://foo.com/foo"},
+		{"http://foo.com:80/foo", "https://foo.com/foo"},
+		{"http://foo.com:8080/foo", "https://foo.com:8080/foo"},
+	} {
+		s, err := upgradeToHTTPS(ss[0])
+
+		assert.Nil(t, err)
+		assert.Equal(t, ss[1], s)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+type jsonLinkGroup struct {
+	URL      string                  `json:"url"`
+	Errors   []*jsonLinkGroupError   `json:"errors"`
+	Warnings []*jsonLinkGroupWarning `json:"warnings,omitempty"`
+}
+
+type jsonLinkGroupError struct {
+	Error         string   `json:"error"`
+	Referrers     []string `json:"referrers"`
+	ReferrerCount int      `json:"referrer_count"`
+}
+
+type jsonLinkGroupWarning struct {
+	Warning       string   `json:"warning"`
+	Referrers     []string `json:"referrers"`
+	ReferrerCount int      `json:"referrer_count"`
+}
+
+func newJSONLinkGroup(g *linkGroup) *jsonLinkGroup {
+	es := make([]*jsonLinkGroupError, 0, len(g.Errors))
+
+	for _, e := range g.Errors {
+		es = append(es, &jsonLinkGroupError{e.Message, e.Referrers, e.ReferrerCount})
+	}
+
+	ws := []*jsonLinkGroupWarning(nil)
+
+	for _, w := range g.Warnings {
+		ws = append(ws, &jsonLinkGroupWarning{w.Message, w.Referrers, w.ReferrerCount})
+	}
+
+	return &jsonLinkGroup{g.URL, es, ws}
+}
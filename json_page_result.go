@@ -1,38 +1,89 @@
 package main
 
 type jsonPageResult struct {
-	URL   string `json:"url"`
-	Links []any  `json:"links"`
+	URL      string   `json:"url"`
+	Warnings []string `json:"warnings,omitempty"`
+	Links    []any    `json:"links"`
 }
 
 type jsonSuccessLinkResult struct {
-	URL    string `json:"url"`
-	Status int    `json:"status"`
+	URL             string          `json:"url"`
+	Status          int             `json:"status"`
+	TimeToFirstByte float64         `json:"time_to_first_byte,omitempty"`
+	Duration        float64         `json:"duration,omitempty"`
+	RedirectURL     string          `json:"redirect_url,omitempty"`
+	Redirects       []*jsonRedirect `json:"redirects,omitempty"`
+	Warnings        []string        `json:"warnings,omitempty"`
+	Source          *jsonLinkSource `json:"source,omitempty"`
 }
 
-type jsonErrorLinkResult struct {
-	URL   string `json:"url"`
-	Error string `json:"error"`
+type jsonRedirect struct {
+	URL      string `json:"url"`
+	Status   int    `json:"status"`
+	Location string `json:"location"`
 }
 
-func newJSONPageResult(r *pageResult, verbose bool) *jsonPageResult {
-	c := len(r.ErrorLinkResults)
+type jsonErrorLinkResult struct {
+	URL    string          `json:"url"`
+	Error  string          `json:"error"`
+	Source *jsonLinkSource `json:"source,omitempty"`
+}
 
-	if verbose {
-		c += len(r.SuccessLinkResults)
-	}
+type jsonLinkSource struct {
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	Element   string `json:"element"`
+	Attribute string `json:"attribute"`
+	Text      string `json:"text,omitempty"`
+}
 
-	ls := make([]any, 0, c)
+func newJSONPageResult(r *pageResult, verbose bool) *jsonPageResult {
+	ls := make([]any, 0, len(r.SuccessLinkResults)+len(r.WarningLinkResults)+len(r.ErrorLinkResults))
 
 	if verbose {
 		for _, r := range r.SuccessLinkResults {
-			ls = append(ls, &jsonSuccessLinkResult{r.URL, r.StatusCode})
+			ls = append(ls, newJSONSuccessLinkResult(r, nil))
 		}
 	}
 
+	for _, r := range r.WarningLinkResults {
+		ls = append(ls, newJSONSuccessLinkResult(&r.successLinkResult, r.Warnings))
+	}
+
 	for _, r := range r.ErrorLinkResults {
-		ls = append(ls, &jsonErrorLinkResult{r.URL, r.Error.Error()})
+		ls = append(ls, &jsonErrorLinkResult{r.URL, r.Error.Error(), newJSONLinkSource(r.Source)})
+	}
+
+	return &jsonPageResult{r.URL, r.Warnings, ls}
+}
+
+func newJSONSuccessLinkResult(r *successLinkResult, warnings []string) *jsonSuccessLinkResult {
+	return &jsonSuccessLinkResult{
+		r.URL,
+		r.StatusCode,
+		r.TimeToFirstByte.Seconds(),
+		r.Duration.Seconds(),
+		r.RedirectURL,
+		newJSONRedirects(r.Redirects),
+		warnings,
+		newJSONLinkSource(r.Source),
+	}
+}
+
+func newJSONLinkSource(s *linkSource) *jsonLinkSource {
+	if s == nil {
+		return nil
+	}
+
+	return &jsonLinkSource{s.Line, s.Column, s.Element, s.Attribute, s.Text}
+}
+
+func newJSONRedirects(hs []*redirectHop) []*jsonRedirect {
+	rs := make([]*jsonRedirect, 0, len(hs))
+
+	for _, h := range hs {
+		rs = append(rs, &jsonRedirect{h.URL, h.StatusCode, h.Location})
 	}
 
-	return &jsonPageResult{r.URL, ls}
+	return rs
 }
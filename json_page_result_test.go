@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/bradleyjkemp/cupaloy"
 	"github.com/stretchr/testify/assert"
@@ -12,10 +13,10 @@ import (
 func TestMarshalErrorJSONPageResult(t *testing.T) {
 	bs, err := json.Marshal(newJSONPageResult(
 		&pageResult{
-			"http://foo.com",
-			[]*successLinkResult{},
-			[]*errorLinkResult{
-				{"http://foo.com/bar", errors.New("baz")},
+			URL:                "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{},
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("baz")},
 			},
 		}, false))
 	assert.Nil(t, err)
@@ -25,11 +26,11 @@ func TestMarshalErrorJSONPageResult(t *testing.T) {
 func TestMarshalSuccessJSONPageResult(t *testing.T) {
 	bs, err := json.Marshal(newJSONPageResult(
 		&pageResult{
-			"http://foo.com",
-			[]*successLinkResult{
-				{"http://foo.com/foo", 200},
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200},
 			},
-			[]*errorLinkResult{},
+			ErrorLinkResults: []*errorLinkResult{},
 		}, false))
 	assert.Nil(t, err)
 	cupaloy.SnapshotT(t, bs)
@@ -38,12 +39,88 @@ func TestMarshalSuccessJSONPageResult(t *testing.T) {
 func TestMarshalVerboseSuccessJSONPageResult(t *testing.T) {
 	bs, err := json.Marshal(newJSONPageResult(
 		&pageResult{
-			"http://foo.com",
-			[]*successLinkResult{
-				{"http://foo.com/foo", 200},
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200},
 			},
-			[]*errorLinkResult{},
+			ErrorLinkResults: []*errorLinkResult{},
 		}, true))
 	assert.Nil(t, err)
 	cupaloy.SnapshotT(t, bs)
 }
+
+func TestMarshalVerboseSuccessJSONPageResultWithDurations(t *testing.T) {
+	bs, err := json.Marshal(newJSONPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{
+					URL:             "http://foo.com/foo",
+					StatusCode:      200,
+					TimeToFirstByte: 250 * time.Millisecond,
+					Duration:        time.Second,
+				},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
+		}, true))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestMarshalWarningJSONPageResult(t *testing.T) {
+	bs, err := json.Marshal(newJSONPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200},
+			},
+			WarningLinkResults: []*warningLinkResult{
+				{successLinkResult{URL: "http://foo.com/bar", StatusCode: 200}, []string{"foo"}},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
+		}, false))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestMarshalJSONPageResultWithLinkSources(t *testing.T) {
+	bs, err := json.Marshal(newJSONPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200, Source: &linkSource{Element: "a", Attribute: "href"}},
+			},
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("baz"), Source: &linkSource{Line: 42, Column: 3, Element: "a", Attribute: "href", Text: "qux"}},
+			},
+		}, true))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestMarshalRedirectedJSONPageResult(t *testing.T) {
+	bs, err := json.Marshal(newJSONPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{
+					URL:         "http://foo.com/foo",
+					StatusCode:  200,
+					RedirectURL: "http://foo.com/bar",
+					Redirects:   []*redirectHop{{"http://foo.com/foo", 301, "http://foo.com/bar"}},
+				},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
+		}, true))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestMarshalPageWarningJSONPageResult(t *testing.T) {
+	bs, err := json.Marshal(newJSONPageResult(
+		&pageResult{URL: "http://foo.com", Warnings: []string{"duplicate id #foo"}},
+		false,
+	))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
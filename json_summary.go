@@ -0,0 +1,48 @@
+package main
+
+import "strconv"
+
+type jsonSummary struct {
+	Pages        int                `json:"pages"`
+	Links        int                `json:"links"`
+	Successes    int                `json:"successes"`
+	Warnings     int                `json:"warnings"`
+	Failures     int                `json:"failures"`
+	Categories   map[string]int     `json:"categories"`
+	StatusCodes  map[string]int     `json:"status_codes"`
+	FailingHosts []*jsonSummaryHost `json:"failing_hosts"`
+	Bytes        int                `json:"bytes"`
+	Duration     float64            `json:"duration"`
+}
+
+type jsonSummaryHost struct {
+	Name     string `json:"name"`
+	Failures int    `json:"failures"`
+}
+
+func newJSONSummary(s *summary) *jsonSummary {
+	cs := make(map[string]int, len(s.StatusCodes))
+
+	for c, n := range s.StatusCodes {
+		cs[strconv.Itoa(c)] = n
+	}
+
+	hs := make([]*jsonSummaryHost, 0, len(s.FailingHosts))
+
+	for _, h := range s.FailingHosts {
+		hs = append(hs, &jsonSummaryHost{h.Name, h.Failures})
+	}
+
+	return &jsonSummary{
+		s.Pages,
+		s.Links,
+		s.Successes,
+		s.Warnings,
+		s.Failures,
+		s.Categories,
+		cs,
+		hs,
+		s.Bytes,
+		s.Duration.Seconds(),
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSONSummary(t *testing.T) {
+	bs, err := json.Marshal(newJSONSummary(newTestSummary()))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func newTestSummary() *summary {
+	return &summary{
+		Pages:        2,
+		Links:        6,
+		Successes:    2,
+		Warnings:     1,
+		Failures:     3,
+		Categories:   map[string]int{"status_code": 2, "timeout": 1},
+		StatusCodes:  map[int]int{404: 2},
+		FailingHosts: []*summaryHost{{"foo.com", 2}, {"bar.com", 1}},
+		Bytes:        50,
+		Duration:     1500 * time.Millisecond,
+	}
+}
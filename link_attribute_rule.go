@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// linkAttributeRule extracts links from an attribute of elements matched with a CSS selector.
+type linkAttributeRule struct {
+	Selector  cssSelector
+	Attribute string
+	// SrcSet parses attribute values as `srcset` attributes.
+	SrcSet bool
+}
+
+// parseLinkAttributeRule parses a rule in a format of `<selector>[<attribute>] [srcset]`
+// where the last attribute selector specifies an attribute of links.
+func parseLinkAttributeRule(s string) (*linkAttributeRule, error) {
+	s = strings.TrimSpace(s)
+	r := &linkAttributeRule{}
+
+	if t, ok := strings.CutSuffix(s, "srcset"); ok && !strings.HasSuffix(s, "]") {
+		s, r.SrcSet = strings.TrimSpace(t), true
+	}
+
+	ss, err := parseCSSSelector(s)
+	if err != nil {
+		return nil, err
+	} else if len(ss) != 1 {
+		return nil, fmt.Errorf("multiple selectors in link attribute rule: %v", s)
+	}
+
+	c := ss[0].Compounds[len(ss[0].Compounds)-1]
+
+	if len(c.Attributes) == 0 || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("link attribute not specified: %v", s)
+	}
+
+	r.Selector = ss
+	r.Attribute = c.Attributes[len(c.Attributes)-1].Name
+
+	return r, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinkAttributeRule(t *testing.T) {
+	for _, c := range []struct {
+		source    string
+		attribute string
+		srcSet    bool
+	}{
+		{"img[data-src]", "data-src", false},
+		{"doc-link[to]", "to", false},
+		{".lazy[data-bg]", "data-bg", false},
+		{"div > img[loading=lazy][data-src]", "data-src", false},
+		{"img[data-srcset] srcset", "data-srcset", true},
+		{" img[data-srcset]  srcset ", "data-srcset", true},
+		{"img[srcset]", "srcset", false},
+	} {
+		r, err := parseLinkAttributeRule(c.source)
+
+		assert.Nil(t, err, c.source)
+		assert.Equal(t, c.attribute, r.Attribute, c.source)
+		assert.Equal(t, c.srcSet, r.SrcSet, c.source)
+	}
+}
+
+func TestParseLinkAttributeRuleError(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"img",
+		"img[",
+		"img[data-src] foo",
+		"img[data-src] .foo",
+		"img[data-src], a[href]",
+		"srcset",
+	} {
+		_, err := parseLinkAttributeRule(s)
+
+		assert.NotNil(t, err, s)
+	}
+}
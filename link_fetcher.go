@@ -1,10 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"mime"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 )
 
 type linkFetcher struct {
@@ -15,100 +18,186 @@ type linkFetcher struct {
 }
 
 type fetchResult struct {
-	StatusCode int
-	Page       page
+	StatusCode      int
+	Page            page
+	ContentType     string
+	BodySize        int
+	TimeToFirstByte time.Duration
+	Duration        time.Duration
+	// RedirectURL is a final URL of redirections or empty if there is none.
+	RedirectURL string
+	Redirects   []*redirectHop
 }
 
 func newLinkFetcher(c httpClient, ps []pageParser, o linkFetcherOptions) *linkFetcher {
 	return &linkFetcher{c, ps, newCache(), o}
 }
 
-// Fetch fetches a link and returns a successful status code and optionally HTML page, or an error.
-func (f *linkFetcher) Fetch(u string) (int, page, error) {
+// Fetch fetches a link and returns a successful result with optionally HTML page, or an error.
+func (f *linkFetcher) Fetch(u string) (*fetchResult, error) {
 	u, fr, err := separateFragment(u)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
-	s, p, err := f.sendRequestWithCache(u)
+	r, err := f.sendRequestWithCache(u)
 	if err != nil {
-		return 0, nil, err
-	} else if p == nil || f.options.IgnoreFragments || fr == "" || strings.HasPrefix(fr, ":~:") {
-		// TODO Support text fragments.
-		return s, p, nil
-	} else if _, ok := p.Fragments()[fr]; !ok {
-		return 0, nil, fmt.Errorf("id #%v not found", fr)
+		return nil, err
 	}
 
-	return s, p, nil
+	if f.options.FollowMetaRefreshes {
+		r, err = f.followMetaRefreshes(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Page == nil || fr == "" || f.ignoresFragment(u, fr) {
+		return r, nil
+	}
+
+	fr, d, _ := strings.Cut(fr, fragmentDirectiveDelimiter)
+
+	if ok, err := findFragment(r.Page, fr); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, fmt.Errorf("id #%v not found", fr)
+	}
+
+	fs, err := parseTextFragments(d)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tf := range fs {
+		if !tf.Match(r.Page.Text()) {
+			return nil, fmt.Errorf("text fragment %q not found", tf.String())
+		}
+	}
+
+	return r, nil
 }
 
-func (f *linkFetcher) sendRequestWithCache(u string) (int, page, error) {
-	x, store := f.cache.LoadOrStore(u)
+func (f *linkFetcher) followMetaRefreshes(r *fetchResult) (*fetchResult, error) {
+	for i := 0; ; i++ {
+		u := metaRefreshTarget(r.Page)
+
+		if u == nil || !f.options.LinkFilterer.IsValid(u) {
+			return r, nil
+		} else if i >= maxMetaRefreshes {
+			return nil, errors.New("too many meta refreshes")
+		}
+
+		s, err := f.sendRequestWithCache(u.String())
+		if err != nil {
+			return nil, fmt.Errorf("broken meta refresh to %v: %w", u.String(), err)
+		}
+
+		t := *s
+		t.TimeToFirstByte = r.TimeToFirstByte
+		t.Duration = r.Duration + s.Duration
+		t.Redirects = append(
+			append(slices.Clone(r.Redirects), &redirectHop{r.Page.URL().String(), r.StatusCode, u.String()}),
+			s.Redirects...,
+		)
+
+		if t.RedirectURL == "" {
+			t.RedirectURL = u.String()
+		}
+
+		r = &t
+	}
+}
+
+func (f *linkFetcher) ignoresFragment(u, fr string) bool {
+	for _, p := range f.options.FragmentPolicies {
+		if p.URLPattern.MatchString(u) {
+			return p.Ignores(fr)
+		}
+	}
+
+	return f.options.IgnoreFragments
+}
+
+func (f *linkFetcher) sendRequestWithCache(u string) (*fetchResult, error) {
+	x, store := f.cache.LoadOrStore(f.options.URLNormalizer.NormalizeString(u))
 
 	if store == nil {
 		if err, ok := x.(error); ok {
-			return 0, nil, err
+			return nil, err
 		}
 
-		r := x.(fetchResult)
-
-		return r.StatusCode, r.Page, nil
+		return x.(*fetchResult), nil
 	}
 
-	s, p, err := f.sendRequest(u)
+	r, err := f.sendRequest(u)
 
 	if err == nil {
-		store(fetchResult{s, p})
+		store(r)
 	} else {
 		store(err)
 	}
 
-	return s, p, err
+	return r, err
 }
 
-func (f *linkFetcher) sendRequest(s string) (int, page, error) {
+func (f *linkFetcher) sendRequest(s string) (*fetchResult, error) {
 	u, err := url.Parse(s)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
 	r, err := f.client.Get(u, nil)
+	n := f.options.URLNormalizer
 
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
-	t := ""
+	ct := ""
 
 	if s := strings.TrimSpace(r.Header("Content-Type")); s != "" {
-		t, _, err = mime.ParseMediaType(s)
+		ct, _, err = mime.ParseMediaType(s)
 
 		if err != nil {
-			return 0, nil, err
+			return nil, err
 		}
 	}
 
 	bs, err := r.Body()
 	if err != nil {
-		return 0, nil, err
+		return nil, err
+	}
+
+	fr := &fetchResult{
+		StatusCode:      r.StatusCode(),
+		ContentType:     ct,
+		BodySize:        len(bs),
+		TimeToFirstByte: r.TimeToFirstByte(),
+		Duration:        r.Duration(),
+		Redirects:       r.Redirects(),
+	}
+
+	if ru, err := url.Parse(r.URL()); err == nil && !equalURLs(n.Normalize(u), n.Normalize(ru)) {
+		fr.RedirectURL = r.URL()
 	}
 
 	for _, pp := range f.pageParsers {
 		u, err := url.Parse(r.URL())
 		if err != nil {
-			return 0, nil, err
+			return nil, err
 		}
 
-		p, err := pp.Parse(u, t, bs)
+		p, err := pp.Parse(u, ct, bs)
 		if err != nil {
-			return 0, nil, err
+			return nil, err
 		} else if p != nil {
-			return r.StatusCode(), p, nil
+			fr.Page = p
+			break
 		}
 	}
 
-	return r.StatusCode(), nil, nil
+	return fr, nil
 }
 
 func separateFragment(s string) (string, string, error) {
@@ -117,8 +206,23 @@ func separateFragment(s string) (string, string, error) {
 		return "", "", err
 	}
 
-	f := u.Fragment
+	// Keep the fragment escaped as delimiters in text fragments can be percent-encoded.
+	f := u.EscapedFragment()
 	u.Fragment = ""
+	u.RawFragment = ""
 
 	return u.String(), f, nil
 }
+
+// equalURLs compares URLs regarding empty paths as root ones.
+func equalURLs(u, v *url.URL) bool {
+	uu, vv := *u, *v
+
+	for _, u := range []*url.URL{&uu, &vv} {
+		if u.Path == "" {
+			u.Path = "/"
+		}
+	}
+
+	return uu.String() == vv.String()
+}
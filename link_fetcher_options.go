@@ -2,4 +2,12 @@ package main
 
 type linkFetcherOptions struct {
 	IgnoreFragments bool
+	// FragmentPolicies are evaluated in order and override IgnoreFragments for matched links.
+	FragmentPolicies []*fragmentPolicy
+	// FollowMetaRefreshes follows meta refreshes of pages as redirections.
+	FollowMetaRefreshes bool
+	// LinkFilterer filters links found in pages rather than given to the fetcher directly.
+	LinkFilterer linkFilterer
+	// URLNormalizer normalizes keys of cached results.
+	URLNormalizer *urlNormalizer
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"net/url"
+	"regexp"
 	"sync"
 	"testing"
 	"time"
@@ -15,7 +16,15 @@ func newTestLinkFetcher(c *fakeHttpClient) *linkFetcher {
 }
 
 func newTestLinkFetcherWithOptions(c *fakeHttpClient, o linkFetcherOptions) *linkFetcher {
-	return newLinkFetcher(c, []pageParser{newSitemapPageParser(newTestLinkFilterer()), newHtmlPageParser(newTestLinkFinder())}, o)
+	return newLinkFetcher(
+		c,
+		[]pageParser{
+			newSitemapPageParser(newTestLinkFilterer()),
+			newHtmlPageParser(newTestLinkFinder()),
+			newCSSPageParser(newCSSLinkFinder(newTestLinkFilterer(), nil)),
+		},
+		o,
+	)
 }
 
 func TestNewFetcher(t *testing.T) {
@@ -34,11 +43,40 @@ func TestLinkFetcherFetch(t *testing.T) {
 			}),
 	)
 
-	s, p, err := f.Fetch("http://foo.com")
+	r, err := f.Fetch("http://foo.com")
+
+	assert.Equal(t, 200, r.StatusCode)
+	assert.NotNil(t, r.Page)
+	assert.Nil(t, err)
+}
+
+func TestLinkFetcherFetchWithRedirection(t *testing.T) {
+	f := newTestLinkFetcher(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeHtmlResponse("http://foo.com/bar", ""), nil
+			}),
+	)
+
+	r, err := f.Fetch("http://foo.com/foo")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "text/html", r.ContentType)
+	assert.Equal(t, "http://foo.com/bar", r.RedirectURL)
+}
+
+func TestLinkFetcherFetchWithoutRedirection(t *testing.T) {
+	f := newTestLinkFetcher(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeHtmlResponse("http://foo.com/", ""), nil
+			}),
+	)
+
+	r, err := f.Fetch("http://foo.com")
 
-	assert.Equal(t, 200, s)
-	assert.NotNil(t, p)
 	assert.Nil(t, err)
+	assert.Equal(t, "", r.RedirectURL)
 }
 
 func TestLinkFetcherFetchFromCache(t *testing.T) {
@@ -58,14 +96,14 @@ func TestLinkFetcherFetchFromCache(t *testing.T) {
 			}),
 	)
 
-	sc, p, err := f.Fetch(s)
-	assert.Equal(t, 200, sc)
-	assert.NotNil(t, p)
+	r, err := f.Fetch(s)
+	assert.Equal(t, 200, r.StatusCode)
+	assert.NotNil(t, r.Page)
 	assert.Nil(t, err)
 
-	sc, p, err = f.Fetch(s)
-	assert.Equal(t, 200, sc)
-	assert.NotNil(t, p)
+	r, err = f.Fetch(s)
+	assert.Equal(t, 200, r.StatusCode)
+	assert.NotNil(t, r.Page)
 	assert.Nil(t, err)
 }
 
@@ -90,7 +128,7 @@ func TestLinkFetcherFetchCacheConcurrency(t *testing.T) {
 
 			time.Sleep(time.Millisecond)
 
-			_, _, err := f.Fetch("http://foo.com")
+			_, err := f.Fetch("http://foo.com")
 			assert.Nil(t, err)
 		}()
 	}
@@ -114,15 +152,20 @@ func TestLinkFetcherFetchWithFragments(t *testing.T) {
 		),
 	)
 
-	sc, p, err := f.Fetch(s + "#foo")
+	r, err := f.Fetch(s + "#foo")
 
-	assert.Equal(t, 200, sc)
-	assert.NotNil(t, p)
+	assert.Equal(t, 200, r.StatusCode)
+	assert.NotNil(t, r.Page)
 	assert.Nil(t, err)
 
-	_, _, err = f.Fetch(s + "#bar")
+	_, err = f.Fetch(s + "#bar")
 
 	assert.Equal(t, "id #bar not found", err.Error())
+
+	for _, fr := range []string{"", "top", "Top"} {
+		_, err = f.Fetch(s + "#" + fr)
+		assert.Nil(t, err)
+	}
 }
 
 func TestLinkFetcherFetchIgnoringFragments(t *testing.T) {
@@ -140,11 +183,43 @@ func TestLinkFetcherFetchIgnoringFragments(t *testing.T) {
 		linkFetcherOptions{IgnoreFragments: true},
 	)
 
-	_, _, err := f.Fetch(s + "#bar")
+	_, err := f.Fetch(s + "#bar")
 	assert.Nil(t, err)
 }
 
-func TestLinkFetcherFetchSkippingTextFragment(t *testing.T) {
+func TestLinkFetcherFetchWithFragmentPolicies(t *testing.T) {
+	f := newTestLinkFetcherWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeHtmlResponse(u.String(), `<p id="foo" />`), nil
+			},
+		),
+		linkFetcherOptions{
+			IgnoreFragments: true,
+			FragmentPolicies: []*fragmentPolicy{
+				{URLPattern: regexp.MustCompile("/app$"), IgnoredPattern: regexp.MustCompile("^/")},
+				{URLPattern: regexp.MustCompile("/docs$")},
+			},
+		},
+	)
+
+	for _, c := range []struct {
+		url string
+		ok  bool
+	}{
+		{"http://foo.com/app#/settings", true},
+		{"http://foo.com/app#foo", true},
+		{"http://foo.com/app#bar", false},
+		{"http://foo.com/docs#foo", true},
+		{"http://foo.com/docs#bar", false},
+		{"http://foo.com/blog#bar", true},
+	} {
+		_, err := f.Fetch(c.url)
+		assert.Equal(t, c.ok, err == nil, c.url)
+	}
+}
+
+func TestLinkFetcherFetchWithTextFragments(t *testing.T) {
 	s := "http://foo.com"
 	f := newTestLinkFetcher(
 		newFakeHttpClient(
@@ -153,12 +228,41 @@ func TestLinkFetcherFetchSkippingTextFragment(t *testing.T) {
 					return nil, errors.New("")
 				}
 
+				return newFakeHtmlResponse(s, `<p id="foo">Hello, <b>world</b>!</p>`), nil
+			},
+		),
+	)
+
+	for _, fr := range []string{
+		":~:text=hello",
+		":~:text=Hello%2C%20world",
+		":~:text=hello,world",
+		"foo:~:text=world",
+		":~:text=hello&text=world",
+	} {
+		_, err := f.Fetch(s + "#" + fr)
+		assert.Nil(t, err, fr)
+	}
+
+	_, err := f.Fetch(s + "#:~:text=foo")
+	assert.Equal(t, `text fragment "foo" not found`, err.Error())
+
+	_, err = f.Fetch(s + "#bar:~:text=world")
+	assert.Equal(t, "id #bar not found", err.Error())
+}
+
+func TestLinkFetcherFetchIgnoringTextFragments(t *testing.T) {
+	s := "http://foo.com"
+	f := newTestLinkFetcherWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
 				return newFakeHtmlResponse(s, ""), nil
 			},
 		),
+		linkFetcherOptions{IgnoreFragments: true},
 	)
 
-	_, _, err := f.Fetch(s + "#:~:text=foo")
+	_, err := f.Fetch(s + "#:~:text=foo")
 	assert.Nil(t, err)
 }
 
@@ -187,12 +291,12 @@ func TestLinkFetcherFetchSitemap(t *testing.T) {
 			}),
 	)
 
-	s, p, err := f.Fetch("http://foo.com/sitemap.xml")
+	r, err := f.Fetch("http://foo.com/sitemap.xml")
 
-	assert.Equal(t, 200, s)
-	assert.NotNil(t, p)
+	assert.Equal(t, 200, r.StatusCode)
+	assert.NotNil(t, r.Page)
 	assert.Nil(t, err)
-	assert.Equal(t, map[string]error{"https://foo.com/": nil}, p.Links())
+	assert.Equal(t, map[string]error{"https://foo.com/": nil}, r.Page.Links())
 }
 
 func TestLinkFetcherFetchSitemapIndex(t *testing.T) {
@@ -214,12 +318,97 @@ func TestLinkFetcherFetchSitemapIndex(t *testing.T) {
 			}),
 	)
 
-	s, p, err := f.Fetch("http://foo.com/sitemap-index.xml")
+	r, err := f.Fetch("http://foo.com/sitemap-index.xml")
+
+	assert.Equal(t, 200, r.StatusCode)
+	assert.NotNil(t, r.Page)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]error{"https://foo.com/sitemap-0.xml": nil}, r.Page.Links())
+}
+
+func TestLinkFetcherFetchCSS(t *testing.T) {
+	f := newTestLinkFetcher(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeCSSResponse(u.String(), `body { background: url("bg.png"); }`), nil
+			},
+		),
+	)
+
+	r, err := f.Fetch("http://foo.com/css/style.css")
+	assert.Nil(t, err)
+
+	assert.Equal(t, map[string]error{"http://foo.com/css/bg.png": nil}, r.Page.Links())
+}
+
+func newTestMetaRefreshHttpClient() *fakeHttpClient {
+	return newFakeHttpClient(
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			switch u.Path {
+			case "/foo":
+				return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/bar" />`), nil
+			case "/bar":
+				return newFakeHtmlResponse(u.String(), `<p id="baz" />`), nil
+			case "/loop":
+				return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/pool" />`), nil
+			case "/pool":
+				return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/loop" />`), nil
+			case "/self":
+				return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="60" />`), nil
+			case "/broken":
+				return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/qux" />`), nil
+			case "/excluded":
+				return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/qux?excluded" />`), nil
+			}
+
+			return nil, errors.New("404")
+		},
+	)
+}
+
+func TestLinkFetcherFetchFollowingMetaRefreshes(t *testing.T) {
+	f := newTestLinkFetcherWithOptions(
+		newTestMetaRefreshHttpClient(),
+		linkFetcherOptions{FollowMetaRefreshes: true},
+	)
+
+	r, err := f.Fetch("http://foo.com/foo#baz")
+	assert.Nil(t, err)
+
+	assert.Equal(t, "http://foo.com/bar", r.RedirectURL)
+	assert.Equal(t, "http://foo.com/bar", r.Page.URL().String())
+	assert.Equal(t, []*redirectHop{{"http://foo.com/foo", 200, "http://foo.com/bar"}}, r.Redirects)
+
+	r, err = f.Fetch("http://foo.com/self")
+	assert.Nil(t, err)
+	assert.Equal(t, "", r.RedirectURL)
+
+	_, err = f.Fetch("http://foo.com/broken")
+	assert.Equal(t, "broken meta refresh to http://foo.com/qux: 404", err.Error())
+
+	_, err = f.Fetch("http://foo.com/loop")
+	assert.Equal(t, "too many meta refreshes", err.Error())
+}
+
+func TestLinkFetcherFetchNotFollowingExcludedMetaRefreshes(t *testing.T) {
+	r, err := newTestLinkFetcherWithOptions(
+		newTestMetaRefreshHttpClient(),
+		linkFetcherOptions{
+			FollowMetaRefreshes: true,
+			LinkFilterer:        newLinkFilterer([]*regexp.Regexp{regexp.MustCompile("excluded")}, nil),
+		},
+	).Fetch("http://foo.com/excluded")
 
-	assert.Equal(t, 200, s)
-	assert.NotNil(t, p)
 	assert.Nil(t, err)
-	assert.Equal(t, map[string]error{"https://foo.com/sitemap-0.xml": nil}, p.Links())
+	assert.Equal(t, "", r.RedirectURL)
+}
+
+func TestLinkFetcherFetchWithoutFollowingMetaRefreshes(t *testing.T) {
+	r, err := newTestLinkFetcher(newTestMetaRefreshHttpClient()).Fetch("http://foo.com/foo")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", r.RedirectURL)
+	assert.Equal(t, "http://foo.com/bar", r.Page.MetaRefreshURL().String())
 }
 
 func TestLinkFetcherFailToFetch(t *testing.T) {
@@ -228,7 +417,7 @@ func TestLinkFetcherFailToFetch(t *testing.T) {
 			return nil, errors.New("")
 		}))
 
-	_, _, err := f.Fetch("http://foo.com")
+	_, err := f.Fetch("http://foo.com")
 
 	assert.NotNil(t, err)
 }
@@ -239,7 +428,7 @@ func TestLinkFetcherFailToParseURL(t *testing.T) {
 			return newFakeHtmlResponse("", ""), nil
 		}))
 
-	_, _, err := f.Fetch(":")
+	_, err := f.Fetch(":")
 
 	assert.NotNil(t, err)
 }
@@ -248,6 +437,7 @@ func TestSeparateFragment(t *testing.T) {
 	for _, ss := range [][3]string{
 		{"http://foo.com#bar", "http://foo.com", "bar"},
 		{"#bar", "", "bar"},
+		{"http://foo.com#:~:text=foo%2Cbar", "http://foo.com", ":~:text=foo%2Cbar"},
 	} {
 		u, id, err := separateFragment(ss[0])
 
@@ -262,3 +452,32 @@ func TestFailToSeparateFragment(t *testing.T) {
 
 	assert.NotNil(t, err)
 }
+
+func TestLinkFetcherFetchWithURLNormalizer(t *testing.T) {
+	n, err := newURLNormalizer([]string{"utm_*"})
+	assert.Nil(t, err)
+
+	us := []string{}
+
+	f := newTestLinkFetcherWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				us = append(us, u.String())
+				return newFakeHtmlResponse(u.String(), ""), nil
+			},
+		),
+		linkFetcherOptions{URLNormalizer: n},
+	)
+
+	for _, s := range []string{
+		"HTTP://Foo.com:80/bar",
+		"http://foo.com/bar",
+		"http://foo.com/bar?utm_source=baz",
+	} {
+		r, err := f.Fetch(s)
+		assert.Nil(t, err)
+		assert.Equal(t, "", r.RedirectURL)
+	}
+
+	assert.Equal(t, []string{"http://Foo.com:80/bar"}, us)
+}
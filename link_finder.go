@@ -11,34 +11,73 @@ import (
 	"golang.org/x/net/html/atom"
 )
 
-var atomToAttributes = map[atom.Atom][]string{
-	atom.A:      {"href"},
-	atom.Frame:  {"src"},
-	atom.Iframe: {"src"},
-	atom.Img:    {"src"},
-	atom.Link:   {"href"},
-	atom.Script: {"src"},
-	atom.Source: {"src", "srcset"},
-	atom.Track:  {"src"},
-	atom.Meta:   {"content"},
+// elementToAttributes maps element names to their attributes of links.
+// SVG elements are included as `xlink:href` attributes are parsed as `href` ones.
+var elementToAttributes = map[string][]string{
+	"a":          {"href"},
+	"area":       {"href"},
+	"audio":      {"src"},
+	"blockquote": {"cite"},
+	"del":        {"cite"},
+	"embed":      {"src"},
+	"form":       {"action"},
+	"frame":      {"src"},
+	"iframe":     {"src"},
+	"image":      {"href"},
+	"img":        {"src", "srcset"},
+	"input":      {"src"},
+	"ins":        {"cite"},
+	"link":       {"href", "imagesrcset"},
+	"meta":       {"content"},
+	"object":     {"data"},
+	"q":          {"cite"},
+	"script":     {"src"},
+	"source":     {"src", "srcset"},
+	"track":      {"src"},
+	"use":        {"href"},
+	"video":      {"src", "poster"},
 }
 
 var imageDescriptorPattern = regexp.MustCompile(`(\S)\s+\S+\s*$`)
 
+const maxLinkTextLength = 80
+
 type linkFinder struct {
-	linkFilterer linkFilterer
+	linkFilterer       linkFilterer
+	cssLinkFinder      cssLinkFinder
+	excludedElements   map[string]struct{}
+	linkAttributeRules []*linkAttributeRule
+	scanSelector       cssSelector
+	ignoreSelector     cssSelector
+	urlNormalizer      *urlNormalizer
 }
 
-func newLinkFinder(f linkFilterer) linkFinder {
-	return linkFinder{f}
+func newLinkFinder(f linkFilterer, o linkFinderOptions) linkFinder {
+	es := make(map[string]struct{}, len(o.ExcludedElements))
+
+	for _, e := range o.ExcludedElements {
+		es[strings.ToLower(e)] = struct{}{}
+	}
+
+	return linkFinder{
+		f,
+		newCSSLinkFinder(f, o.URLNormalizer),
+		es,
+		o.LinkAttributeRules,
+		o.ScanSelector,
+		o.IgnoreSelector,
+		o.URLNormalizer,
+	}
 }
 
-func (f linkFinder) Find(n *html.Node, base *url.URL) map[string]error {
+// Find finds links in a node and their sources located with a source map.
+func (f linkFinder) Find(n *html.Node, base *url.URL, m htmlSourceMap) (map[string]error, map[string]*linkSource) {
 	ls := map[string]error{}
+	ss := map[string]*linkSource{}
 
 	for _, n := range scrape.FindAllNested(n, func(n *html.Node) bool {
-		_, ok := atomToAttributes[n.DataAtom]
-		return ok
+		_, ok := elementToAttributes[n.Data]
+		return n.Type == html.ElementNode && ok && !f.isExcluded(n.Data) && f.isScanned(n)
 	}) {
 
 		// `preconnect` and `dns-prefetch` links are not HTTP resources.
@@ -49,32 +88,225 @@ func (f linkFinder) Find(n *html.Node, base *url.URL) map[string]error {
 			}
 		}
 
-		for _, a := range atomToAttributes[n.DataAtom] {
-			ss := f.parseLinks(n, a)
+		// Only image buttons have links.
+		if n.DataAtom == atom.Input && !strings.EqualFold(scrape.Attr(n, "type"), "image") {
+			continue
+		}
+
+		for _, a := range elementToAttributes[n.Data] {
+			if f.isExcluded(n.Data + "[" + a + "]") {
+				continue
+			}
+
+			f.addLinks(ls, ss, base, f.parseLinks(n, a), newLinkSource(n, a, m))
+		}
+	}
+
+	f.findLinksWithRules(n, base, m, ls, ss)
+	f.findCSSLinks(n, base, m, ls, ss)
+	f.urlNormalizer.DeduplicateLinks(ls, ss)
+
+	return ls, ss
+}
+
+// findLinksWithRules finds links in attributes specified by user-defined rules.
+func (f linkFinder) findLinksWithRules(
+	n *html.Node,
+	base *url.URL,
+	m htmlSourceMap,
+	ls map[string]error,
+	ss map[string]*linkSource,
+) {
+	if len(f.linkAttributeRules) == 0 {
+		return
+	}
+
+	for _, n := range scrape.FindAllNested(n, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && !f.isExcluded(n.Data) && f.isScanned(n)
+	}) {
+		for _, r := range f.linkAttributeRules {
+			if f.isExcluded(n.Data+"["+r.Attribute+"]") || !r.Selector.Match(n) {
+				continue
+			}
+
+			vs := []string{scrape.Attr(n, r.Attribute)}
+
+			if r.SrcSet {
+				vs = f.parseSrcSet(vs[0])
+			}
+
+			f.addLinks(ls, ss, base, vs, newLinkSource(n, r.Attribute, m))
+		}
+	}
+}
+
+func (f linkFinder) addLinks(
+	ls map[string]error,
+	ss map[string]*linkSource,
+	base *url.URL,
+	vs []string,
+	src *linkSource,
+) {
+	for _, s := range vs {
+		s := f.trimUrl(s)
+
+		if s == "" {
+			continue
+		}
+
+		u, err := url.Parse(s)
+		if err != nil {
+			ls[s] = err
+			addLinkSource(ss, s, src)
+			continue
+		}
+
+		u = base.ResolveReference(u)
+
+		if f.linkFilterer.IsValid(u) {
+			ls[u.String()] = nil
+			addLinkSource(ss, u.String(), src)
+		}
+	}
+}
+
+func (f linkFinder) isExcluded(e string) bool {
+	_, ok := f.excludedElements[e]
+	return ok
+}
+
+// isScanned returns true if an element is in subtrees to scan and not in ones to ignore.
+func (f linkFinder) isScanned(n *html.Node) bool {
+	s := f.scanSelector == nil
+
+	for n := n; n != nil && n.Type == html.ElementNode; n = n.Parent {
+		if f.ignoreSelector != nil && f.ignoreSelector.Match(n) {
+			return false
+		} else if !s && f.scanSelector.Match(n) {
+			s = true
+		}
+	}
+
+	return s
+}
+
+// findCSSLinks finds links in style attributes and style elements.
+func (f linkFinder) findCSSLinks(
+	n *html.Node,
+	base *url.URL,
+	m htmlSourceMap,
+	ls map[string]error,
+	ss map[string]*linkSource,
+) {
+	add := func(css string, src *linkSource) {
+		// Resources referred by CSS are always loaded as a part of a page.
+		src.Subresource = true
+
+		for u, err := range f.cssLinkFinder.Find(css, base) {
+			ls[u] = err
+			addLinkSource(ss, u, src)
+		}
+	}
+
+	for _, n := range scrape.FindAllNested(n, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && f.isScanned(n)
+	}) {
+		if s := scrape.Attr(n, "style"); s != "" && !f.isExcluded(n.Data) && !f.isExcluded(n.Data+"[style]") {
+			add(s, newLinkSource(n, "style", m))
+		}
+
+		if n.DataAtom == atom.Style && !f.isExcluded(n.Data) {
+			s := scrape.Text(n)
+			src := &linkSource{Element: n.Data}
+
+			if p, ok := m.Find(n.Data, "", s); ok {
+				src.Line, src.Column = p.Line, p.Column
+			}
+
+			add(s, src)
+		}
+	}
+}
+
+func newLinkSource(n *html.Node, a string, m htmlSourceMap) *linkSource {
+	s := &linkSource{Element: n.Data, Attribute: htmlAttributeName(n, a), Text: linkText(n), Subresource: isSubresource(n)}
 
-			for _, s := range ss {
-				s := f.trimUrl(s)
+	if p, ok := m.Find(n.Data, s.Attribute, scrape.Attr(n, a)); ok {
+		s.Line, s.Column = p.Line, p.Column
+	}
+
+	return s
+}
 
-				if s == "" {
-					continue
-				}
+// htmlAttributeName returns a name of an attribute in HTML source with its namespace prefix.
+func htmlAttributeName(n *html.Node, k string) string {
+	for _, a := range n.Attr {
+		if a.Key == k && a.Namespace != "" {
+			return a.Namespace + ":" + a.Key
+		}
+	}
 
-				u, err := url.Parse(s)
-				if err != nil {
-					ls[s] = err
-					continue
-				}
+	return k
+}
 
-				u = base.ResolveReference(u)
+// addLinkSource adds a source of a link keeping its first occurrence.
+// A subresource occurrence takes precedence so that links loaded as parts of pages are never missed.
+func addLinkSource(ss map[string]*linkSource, u string, s *linkSource) {
+	if t, ok := ss[u]; !ok || !t.Subresource && s.Subresource {
+		ss[u] = s
+	}
+}
 
-				if f.linkFilterer.IsValid(u) {
-					ls[u.String()] = nil
-				}
+// isSubresource returns true if an element loads its link as a part of a page.
+func isSubresource(n *html.Node) bool {
+	switch n.Data {
+	case "a", "area", "blockquote", "del", "form", "ins", "meta", "q":
+		return false
+	case "link":
+		for _, s := range strings.Fields(strings.ToLower(scrape.Attr(n, "rel"))) {
+			switch s {
+			case "stylesheet", "icon", "apple-touch-icon", "manifest", "preload", "modulepreload":
+				return true
 			}
 		}
+
+		return false
+	}
+
+	return true
+}
+
+// linkText returns visible text of a link element falling back to alternative texts.
+func linkText(n *html.Node) string {
+	s := ""
+
+	if n.DataAtom == atom.A {
+		s = strings.Join(strings.Fields(scrape.Text(n)), " ")
+	}
+
+	if s == "" {
+		s = scrape.Attr(n, "alt")
+	}
+
+	if s == "" {
+		if n, ok := scrape.Find(n, scrape.ByTag(atom.Img)); ok {
+			s = scrape.Attr(n, "alt")
+		}
 	}
 
-	return ls
+	for _, a := range []string{"title", "aria-label"} {
+		if s == "" {
+			s = scrape.Attr(n, a)
+		}
+	}
+
+	s = strings.TrimSpace(s)
+
+	if rs := []rune(s); len(rs) > maxLinkTextLength {
+		s = string(rs[:maxLinkTextLength-1]) + "…"
+	}
+
+	return s
 }
 
 func (f linkFinder) parseLinks(n *html.Node, a string) []string {
@@ -82,15 +314,19 @@ func (f linkFinder) parseLinks(n *html.Node, a string) []string {
 	ss := []string{}
 
 	switch a {
-	case "srcset":
-		for _, s := range strings.Split(s, ",") {
-			ss = append(ss, f.trimUrl(imageDescriptorPattern.ReplaceAllString(s, "$1")))
-		}
+	case "srcset", "imagesrcset":
+		ss = f.parseSrcSet(s)
 	case "content":
 		switch scrape.Attr(n, "property") {
 		case "og:image", "og:audio", "og:video", "og:image:url", "og:image:secure_url", "twitter:image":
 			ss = append(ss, s)
 		}
+
+		if isMetaRefresh(scrape.Attr(n, "http-equiv")) {
+			if s, ok := parseMetaRefresh(s); ok {
+				ss = append(ss, s)
+			}
+		}
 	default:
 		ss = append(ss, s)
 	}
@@ -98,6 +334,16 @@ func (f linkFinder) parseLinks(n *html.Node, a string) []string {
 	return ss
 }
 
+func (f linkFinder) parseSrcSet(s string) []string {
+	ss := []string{}
+
+	for _, s := range strings.Split(s, ",") {
+		ss = append(ss, f.trimUrl(imageDescriptorPattern.ReplaceAllString(s, "$1")))
+	}
+
+	return ss
+}
+
 func (linkFinder) trimUrl(s string) string {
 	s = strings.TrimSpace(s)
 
@@ -0,0 +1,12 @@
+package main
+
+type linkFinderOptions struct {
+	// ExcludedElements are in a format of `<element>` or `<element>[<attribute>]`.
+	ExcludedElements   []string
+	LinkAttributeRules []*linkAttributeRule
+	// ScanSelector restricts links to subtrees matched with it if it is not nil.
+	ScanSelector cssSelector
+	// IgnoreSelector excludes links in subtrees matched with it if it is not nil.
+	IgnoreSelector cssSelector
+	URLNormalizer  *urlNormalizer
+}
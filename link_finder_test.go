@@ -2,16 +2,19 @@ package main
 
 import (
 	"fmt"
+	"maps"
 	"net/url"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/yhat/scrape"
 	"golang.org/x/net/html"
 )
 
 func newTestLinkFinder() linkFinder {
-	return newLinkFinder(newTestLinkFilterer())
+	return newLinkFinder(newTestLinkFilterer(), linkFinderOptions{})
 }
 
 func TestLinkFinderFindLinks(t *testing.T) {
@@ -24,6 +27,7 @@ func TestLinkFinderFindLinks(t *testing.T) {
 	}{
 		{``, 0},
 		{`<a href="" />`, 0},
+		{`<p>a</p><!--img-->`, 0},
 		{`<a href="/" />`, 1},
 		{`<a href="/foo" />`, 1},
 		// TODO: Test <frame> tag.
@@ -37,13 +41,34 @@ func TestLinkFinderFindLinks(t *testing.T) {
 		{`<track src="/foo.vtt" />`, 1},
 		{`<a href="/"><img src="/foo.png" /></a>`, 2},
 		{`<a href="/" /><a href="/" />`, 1},
+		{`<img srcset="/foo.png 1x, /bar.png 2x" />`, 2},
+		{`<video src="/foo.mp4" poster="/foo.png"></video>`, 2},
+		{`<audio src="/foo.mp3"></audio>`, 1},
+		{`<object data="/foo.swf"></object>`, 1},
+		{`<embed src="/foo.swf" />`, 1},
+		{`<map><area href="/foo" /></map>`, 1},
+		{`<input type="image" src="/foo.png" />`, 1},
+		{`<input type="IMAGE" src="/foo.png" />`, 1},
+		{`<input type="text" src="/foo.png" />`, 0},
+		{`<form action="/foo"></form>`, 1},
+		{`<blockquote cite="/foo"></blockquote>`, 1},
+		{`<q cite="/foo"></q>`, 1},
+		{`<ins cite="/foo"></ins><del cite="/bar"></del>`, 2},
+		{`<link rel="preload" as="image" href="/foo.png" imagesrcset="/bar.png 2x" />`, 2},
+		{`<svg><use href="/foo.svg#bar" /></svg>`, 1},
+		{`<svg><use xlink:href="/foo.svg#bar" /></svg>`, 1},
+		{`<svg><image href="/foo.png" /></svg>`, 1},
+		{`<svg><a xlink:href="/foo"></a></svg>`, 1},
+		{`<meta http-equiv="refresh" content="0; url=/foo" />`, 1},
+		{`<meta http-equiv="refresh" content="60" />`, 0},
 	} {
 		n, err := html.Parse(strings.NewReader(htmlWithBody(c.html)))
 		assert.Nil(t, err)
 
+		ls, _ := newTestLinkFinder().Find(n, b, nil)
 		s, e := 0, 0
 
-		for _, err := range newTestLinkFinder().Find(n, b) {
+		for _, err := range ls {
 			if err == nil {
 				s++
 			} else {
@@ -65,7 +90,7 @@ func TestLinkFinderFindLinkWithoutEncodedSpaces(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	err, ok := ls["http://foo.com/a%20b"]
 	assert.True(t, ok)
@@ -81,7 +106,7 @@ func TestLinkFinderFindLinkWithoutSpacesNotEncoded(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	err, ok := ls["http://foo.com/a%20b"]
 	assert.True(t, ok)
@@ -97,7 +122,7 @@ func TestLinkFinderFindLinkWithLeadingAndTrailingSpaces(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	err, ok := ls["http://foo.com"]
 	assert.True(t, ok)
@@ -111,7 +136,7 @@ func TestLinkFinderFailWithInvalidURL(t *testing.T) {
 	n, err := html.Parse(strings.NewReader(htmlWithBody(`<a href=":" />`)))
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	assert.Equal(t, 1, len(ls))
 	assert.NotNil(t, ls[":"])
@@ -126,7 +151,7 @@ func TestLinkFinderFindLinkInSrcSet(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	err, ok := ls["http://foo.com/foo.png"]
 	assert.True(t, ok)
@@ -142,7 +167,7 @@ func TestLinkFinderFindMultipleLinksInSrcSet(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	err, ok := ls["http://foo.com/foo.png"]
 	assert.True(t, ok)
@@ -162,7 +187,7 @@ func TestLinkFinderFindMultipleLinksInSrcSetWithDescriptors(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	err, ok := ls["http://foo.com/foo.png"]
 	assert.True(t, ok)
@@ -182,7 +207,7 @@ func TestLinkFinderFindMetaTags(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	err, ok := ls["http://foo.com/foo.png"]
 	assert.True(t, ok)
@@ -198,7 +223,7 @@ func TestLinkFinderFindDataSchemeLinkWithSpaces(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	assert.Len(t, ls, 0)
 }
@@ -212,7 +237,7 @@ func TestLinkFinderIgnoreMetaTags(t *testing.T) {
 	)
 	assert.Nil(t, err)
 
-	ls := newTestLinkFinder().Find(n, b)
+	ls, _ := newTestLinkFinder().Find(n, b, nil)
 
 	assert.Len(t, ls, 0)
 }
@@ -232,9 +257,10 @@ func TestLinkFinderIgnorePreconnect(t *testing.T) {
 		n, err := html.Parse(strings.NewReader(htmlWithBody(c.html)))
 		assert.Nil(t, err)
 
+		ls, _ := newTestLinkFinder().Find(n, b, nil)
 		s, e := 0, 0
 
-		for _, err := range newTestLinkFinder().Find(n, b) {
+		for _, err := range ls {
 			if err == nil {
 				s++
 			} else {
@@ -254,3 +280,351 @@ func htmlWithBody(b string) string {
 func htmlWithHead(b string) string {
 	return fmt.Sprintf(`<html><head>%v</head><body><p>hi</p></body></html>`, b)
 }
+
+func TestLinkFinderFindLinkSources(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	s := "<html><body>\n<a href=\"/foo\">foo</a>\n<img src=\"/bar.png\" srcset=\"/baz.png 2x\" /></body></html>"
+	n, err := html.Parse(strings.NewReader(s))
+	assert.Nil(t, err)
+
+	_, ss := newTestLinkFinder().Find(n, b, newHtmlSourceMap([]byte(s)))
+
+	assert.Equal(
+		t,
+		map[string]*linkSource{
+			"http://foo.com/foo":     {Line: 2, Column: 1, Element: "a", Attribute: "href", Text: "foo"},
+			"http://foo.com/bar.png": {Line: 3, Column: 1, Element: "img", Attribute: "src", Subresource: true},
+			"http://foo.com/baz.png": {Line: 3, Column: 1, Element: "img", Attribute: "srcset", Subresource: true},
+		},
+		ss,
+	)
+}
+
+func TestLinkFinderFindSubresourceLinkSource(t *testing.T) {
+	for _, s := range []string{
+		`<a href="/foo.png"><img src="/foo.png" /></a>`,
+		`<img src="/foo.png" /><a href="/foo.png">foo</a>`,
+	} {
+		n, err := html.Parse(strings.NewReader(htmlWithBody(s)))
+		assert.Nil(t, err)
+
+		_, ss := newTestLinkFinder().Find(n, parseURL(t, "http://foo.com"), nil)
+
+		assert.Equal(
+			t,
+			&linkSource{Element: "img", Attribute: "src", Subresource: true},
+			ss["http://foo.com/foo.png"],
+			s,
+		)
+	}
+}
+
+func TestLinkFinderFindLinkSourcesWithoutSourceMap(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	n, err := html.Parse(strings.NewReader(htmlWithBody(`<a href="/foo" />`)))
+	assert.Nil(t, err)
+
+	_, ss := newTestLinkFinder().Find(n, b, nil)
+
+	assert.Equal(t, map[string]*linkSource{"http://foo.com/foo": {Element: "a", Attribute: "href"}}, ss)
+}
+
+func TestLinkText(t *testing.T) {
+	for _, c := range []struct {
+		html string
+		text string
+	}{
+		{`<a href="/">foo</a>`, "foo"},
+		{"<a href=\"/\">\n  foo\n  <b>bar</b>\n</a>", "foo bar"},
+		{`<a href="/"><img src="/foo.png" alt="foo" /></a>`, "foo"},
+		{`<a href="/" title="foo"></a>`, "foo"},
+		{`<a href="/" aria-label="foo"></a>`, "foo"},
+		{`<img src="/foo.png" alt="foo" title="bar" />`, "foo"},
+		{`<img src="/foo.png" title="bar" />`, "bar"},
+		{`<script src="/foo.js">foo</script>`, ""},
+		{`<a href="/">` + strings.Repeat("a", 100) + `</a>`, strings.Repeat("a", 79) + "…"},
+	} {
+		n, err := html.Parse(strings.NewReader(htmlWithBody(c.html)))
+		assert.Nil(t, err)
+
+		n, ok := scrape.Find(n, func(n *html.Node) bool {
+			_, ok := elementToAttributes[n.Data]
+			return ok
+		})
+		assert.True(t, ok)
+
+		assert.Equal(t, c.text, linkText(n))
+	}
+}
+
+func TestIsSubresource(t *testing.T) {
+	for _, c := range []struct {
+		html        string
+		subresource bool
+	}{
+		{`<a href="/foo">foo</a>`, false},
+		{`<img src="/foo.png" />`, true},
+		{`<script src="/foo.js"></script>`, true},
+		{`<iframe src="/foo"></iframe>`, true},
+		{`<link rel="stylesheet" href="/foo.css" />`, true},
+		{`<link rel="Shortcut Icon" href="/foo.ico" />`, true},
+		{`<link rel="canonical" href="/foo" />`, false},
+		{`<form action="/foo"></form>`, false},
+		{`<map><area href="/foo" /></map>`, false},
+		{`<blockquote cite="/foo"></blockquote>`, false},
+		{`<video poster="/foo.png"></video>`, true},
+		{`<svg><use href="/foo.svg" /></svg>`, true},
+		{`<meta property="og:image" content="/foo.png" />`, false},
+	} {
+		n, err := html.Parse(strings.NewReader(htmlWithBody(c.html)))
+		assert.Nil(t, err)
+
+		n, ok := scrape.Find(n, func(n *html.Node) bool {
+			_, ok := elementToAttributes[n.Data]
+			return ok
+		})
+		assert.True(t, ok)
+
+		assert.Equal(t, c.subresource, isSubresource(n))
+	}
+}
+
+func TestLinkFinderFindLinksInStyles(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	for _, c := range []struct {
+		html string
+		urls []string
+	}{
+		{`<div style="background-image: url(foo.png)"></div>`, []string{"http://foo.com/foo.png"}},
+		{`<div style="color: red"></div>`, []string{}},
+		{`<style>body { background: url("/foo.png"); }</style>`, []string{"http://foo.com/foo.png"}},
+		{`<style>@import "foo.css"; @font-face { src: url(bar.woff); }</style>`, []string{"http://foo.com/foo.css", "http://foo.com/bar.woff"}},
+		{`<a href="/foo" style="background: url(/bar.png)">foo</a>`, []string{"http://foo.com/foo", "http://foo.com/bar.png"}},
+	} {
+		n, err := html.Parse(strings.NewReader(htmlWithBody(c.html)))
+		assert.Nil(t, err)
+
+		ls, _ := newTestLinkFinder().Find(n, b, nil)
+
+		assert.ElementsMatch(t, c.urls, slices.Collect(maps.Keys(ls)), c.html)
+	}
+}
+
+func TestLinkFinderFindLinkSourcesInStyles(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	s := "<html><head>\n<style>body { background: url(/foo.png); }</style></head><body>\n<a href=\"/bar\" style=\"background: url(/baz.png)\">bar</a></body></html>"
+	n, err := html.Parse(strings.NewReader(s))
+	assert.Nil(t, err)
+
+	_, ss := newTestLinkFinder().Find(n, b, newHtmlSourceMap([]byte(s)))
+
+	assert.Equal(
+		t,
+		map[string]*linkSource{
+			"http://foo.com/foo.png": {Line: 2, Column: 8, Element: "style", Subresource: true},
+			"http://foo.com/bar":     {Line: 3, Column: 1, Element: "a", Attribute: "href", Text: "bar"},
+			"http://foo.com/baz.png": {Line: 3, Column: 1, Element: "a", Attribute: "style", Text: "bar", Subresource: true},
+		},
+		ss,
+	)
+}
+
+func TestLinkFinderExcludeElements(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	for _, c := range []struct {
+		html     string
+		elements []string
+		urls     []string
+	}{
+		{`<form action="/foo"></form><a href="/bar"></a>`, []string{"form"}, []string{"http://foo.com/bar"}},
+		{`<form action="/foo"></form>`, []string{"FORM"}, []string{}},
+		{`<video src="/foo.mp4" poster="/foo.png"></video>`, []string{"video[poster]"}, []string{"http://foo.com/foo.mp4"}},
+		{`<div style="background: url(/foo.png)"></div>`, []string{"div[style]"}, []string{}},
+		{`<style>div { background: url(/foo.png); }</style>`, []string{"style"}, []string{}},
+	} {
+		n, err := html.Parse(strings.NewReader(htmlWithBody(c.html)))
+		assert.Nil(t, err)
+
+		ls, _ := newLinkFinder(newTestLinkFilterer(), linkFinderOptions{ExcludedElements: c.elements}).Find(n, b, nil)
+
+		assert.ElementsMatch(t, c.urls, slices.Collect(maps.Keys(ls)), c.html)
+	}
+}
+
+func TestLinkFinderFindLinkSourcesWithNamespacedAttributes(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	s := `<html><body><svg><use xlink:href="/foo.svg#bar" /></svg></body></html>`
+	n, err := html.Parse(strings.NewReader(s))
+	assert.Nil(t, err)
+
+	_, ss := newTestLinkFinder().Find(n, b, newHtmlSourceMap([]byte(s)))
+
+	assert.Equal(
+		t,
+		map[string]*linkSource{
+			"http://foo.com/foo.svg#bar": {Line: 1, Column: 18, Element: "use", Attribute: "xlink:href", Subresource: true},
+		},
+		ss,
+	)
+}
+
+func TestLinkFinderFindLinksWithRules(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	rs := []*linkAttributeRule{}
+
+	for _, s := range []string{"img[data-src]", "img[data-srcset] srcset", "doc-link[to]"} {
+		r, err := parseLinkAttributeRule(s)
+		assert.Nil(t, err)
+		rs = append(rs, r)
+	}
+
+	for _, c := range []struct {
+		html     string
+		elements []string
+		urls     []string
+	}{
+		{`<img data-src="/foo.png" />`, nil, []string{"http://foo.com/foo.png"}},
+		{`<img data-srcset="/foo.png 1x, /bar.png 2x" />`, nil, []string{"http://foo.com/foo.png", "http://foo.com/bar.png"}},
+		{`<doc-link to="/foo">foo</doc-link>`, nil, []string{"http://foo.com/foo"}},
+		{`<div data-src="/foo.png"></div>`, nil, []string{}},
+		{`<img data-src="/foo.png" />`, []string{"img[data-src]"}, []string{}},
+		{`<doc-link to="/foo">foo</doc-link>`, []string{"doc-link"}, []string{}},
+	} {
+		n, err := html.Parse(strings.NewReader(htmlWithBody(c.html)))
+		assert.Nil(t, err)
+
+		ls, _ := newLinkFinder(
+			newTestLinkFilterer(),
+			linkFinderOptions{ExcludedElements: c.elements, LinkAttributeRules: rs},
+		).Find(n, b, nil)
+
+		assert.ElementsMatch(t, c.urls, slices.Collect(maps.Keys(ls)), c.html)
+	}
+}
+
+func TestLinkFinderFindLinkSourcesWithRules(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	r, err := parseLinkAttributeRule("doc-link[to]")
+	assert.Nil(t, err)
+
+	s := "<html><body>\n  <doc-link to=\"/foo\">foo</doc-link></body></html>"
+	n, err := html.Parse(strings.NewReader(s))
+	assert.Nil(t, err)
+
+	_, ss := newLinkFinder(
+		newTestLinkFilterer(),
+		linkFinderOptions{LinkAttributeRules: []*linkAttributeRule{r}},
+	).Find(n, b, newHtmlSourceMap([]byte(s)))
+
+	assert.Equal(
+		t,
+		map[string]*linkSource{
+			"http://foo.com/foo": {Line: 2, Column: 3, Element: "doc-link", Attribute: "to", Subresource: true},
+		},
+		ss,
+	)
+}
+
+func TestLinkFinderFindLinksWithSelectors(t *testing.T) {
+	b, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	s := `<nav><a href="/nav"></a></nav>
+		<main>
+			<a href="/main"></a>
+			<div class="widget"><a href="/widget"></a></div>
+			<p style="background: url(/bg.png)"></p>
+		</main>
+		<footer><a href="/footer"></a><div class="widget"><img src="/widget.png" /></div></footer>`
+
+	for _, c := range []struct {
+		scan   string
+		ignore string
+		urls   []string
+	}{
+		{
+			"",
+			"",
+			[]string{
+				"http://foo.com/nav",
+				"http://foo.com/main",
+				"http://foo.com/widget",
+				"http://foo.com/bg.png",
+				"http://foo.com/footer",
+				"http://foo.com/widget.png",
+			},
+		},
+		{
+			"main",
+			"",
+			[]string{"http://foo.com/main", "http://foo.com/widget", "http://foo.com/bg.png"},
+		},
+		{
+			"",
+			"footer .widget, nav",
+			[]string{"http://foo.com/main", "http://foo.com/widget", "http://foo.com/bg.png", "http://foo.com/footer"},
+		},
+		{
+			"main",
+			".widget",
+			[]string{"http://foo.com/main", "http://foo.com/bg.png"},
+		},
+	} {
+		o := linkFinderOptions{}
+
+		if c.scan != "" {
+			o.ScanSelector, err = parseCSSSelector(c.scan)
+			assert.Nil(t, err)
+		}
+
+		if c.ignore != "" {
+			o.IgnoreSelector, err = parseCSSSelector(c.ignore)
+			assert.Nil(t, err)
+		}
+
+		n, err := html.Parse(strings.NewReader(htmlWithBody(s)))
+		assert.Nil(t, err)
+
+		ls, _ := newLinkFinder(newTestLinkFilterer(), o).Find(n, b, nil)
+
+		assert.ElementsMatch(t, c.urls, slices.Collect(maps.Keys(ls)), c)
+	}
+}
+
+func TestLinkFinderFindLinksWithURLNormalizer(t *testing.T) {
+	n, err := html.Parse(strings.NewReader(htmlWithBody(`
+		<a href="HTTP://Foo.com:80/bar?b=2&a=1&utm_source=baz">foo</a>
+		<a href="/bar?a=1&b=2">bar</a>
+		<div style="background: url(/bar?fbclid=qux&b=2&a=1)"></div>
+	`)))
+	assert.Nil(t, err)
+
+	u, err := newURLNormalizer([]string{"utm_*", "fbclid"})
+	assert.Nil(t, err)
+
+	ls, ss := newLinkFinder(newTestLinkFilterer(), linkFinderOptions{URLNormalizer: u}).Find(
+		n,
+		parseURL(t, "http://foo.com"),
+		nil,
+	)
+
+	assert.Equal(t, map[string]error{"http://Foo.com:80/bar?b=2&a=1&utm_source=baz": nil}, ls)
+	assert.Equal(t, []string{"http://Foo.com:80/bar?b=2&a=1&utm_source=baz"}, slices.Collect(maps.Keys(ss)))
+	assert.True(t, ss["http://Foo.com:80/bar?b=2&a=1&utm_source=baz"].Subresource)
+}
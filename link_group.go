@@ -0,0 +1,109 @@
+package main
+
+import (
+	"maps"
+	"slices"
+)
+
+// linkGroup is a broken or warned link grouped with pages referring to it.
+type linkGroup struct {
+	URL      string
+	Errors   []*linkGroupMessage
+	Warnings []*linkGroupMessage
+}
+
+type linkGroupMessage struct {
+	Message string
+	// Referrers are pages referring to a link and can be truncated.
+	Referrers     []string
+	ReferrerCount int
+}
+
+// linkGroupCollector inverts page results into groups of broken or warned links.
+type linkGroupCollector struct {
+	maxReferrers int
+	errors       map[string]map[string]map[string]struct{}
+	warnings     map[string]map[string]map[string]struct{}
+	ok           bool
+}
+
+func newLinkGroupCollector(maxReferrers int) *linkGroupCollector {
+	return &linkGroupCollector{
+		maxReferrers,
+		map[string]map[string]map[string]struct{}{},
+		map[string]map[string]map[string]struct{}{},
+		true,
+	}
+}
+
+func (c *linkGroupCollector) Add(r *pageResult) {
+	for _, l := range r.ErrorLinkResults {
+		addLinkGroupReferrer(c.errors, l.URL, l.Error.Error(), r.URL)
+	}
+
+	for _, l := range r.WarningLinkResults {
+		for _, w := range l.Warnings {
+			addLinkGroupReferrer(c.warnings, l.URL, w, r.URL)
+		}
+	}
+
+	// Page warnings are grouped by pages themselves.
+	for _, w := range r.Warnings {
+		addLinkGroupReferrer(c.warnings, r.URL, w, r.URL)
+	}
+
+	c.ok = c.ok && r.OK()
+}
+
+// Groups returns link groups sorted by URLs.
+func (c *linkGroupCollector) Groups() []*linkGroup {
+	us := slices.Collect(maps.Keys(c.errors))
+
+	for u := range c.warnings {
+		if _, ok := c.errors[u]; !ok {
+			us = append(us, u)
+		}
+	}
+
+	slices.Sort(us)
+	gs := make([]*linkGroup, 0, len(us))
+
+	for _, u := range us {
+		gs = append(gs, &linkGroup{u, c.messages(c.errors[u]), c.messages(c.warnings[u])})
+	}
+
+	return gs
+}
+
+func (c *linkGroupCollector) OK() bool {
+	return c.ok
+}
+
+func (c *linkGroupCollector) messages(rs map[string]map[string]struct{}) []*linkGroupMessage {
+	ms := []*linkGroupMessage(nil)
+
+	for _, m := range slices.Sorted(maps.Keys(rs)) {
+		ps := slices.Sorted(maps.Keys(rs[m]))
+		n := len(ps)
+
+		if c.maxReferrers > 0 && n > c.maxReferrers {
+			ps = ps[:c.maxReferrers]
+		}
+
+		ms = append(ms, &linkGroupMessage{m, ps, n})
+	}
+
+	return ms
+}
+
+func addLinkGroupReferrer(rs map[string]map[string]map[string]struct{}, u, m, p string) {
+	if _, ok := rs[u]; !ok {
+		rs[u] = map[string]map[string]struct{}{}
+	}
+
+	if _, ok := rs[u][m]; !ok {
+		rs[u][m] = map[string]struct{}{}
+	}
+
+	rs[u][m][p] = struct{}{}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkGroupCollectorGroups(t *testing.T) {
+	c := newLinkGroupCollector(0)
+
+	c.Add(&pageResult{
+		URL: "http://foo.com/foo",
+		ErrorLinkResults: []*errorLinkResult{
+			{URL: "http://foo.com/bar", Error: errors.New("404")},
+			{URL: "http://foo.com/baz", Error: errors.New("500")},
+		},
+	})
+	c.Add(&pageResult{
+		URL: "http://foo.com",
+		ErrorLinkResults: []*errorLinkResult{
+			{URL: "http://foo.com/bar", Error: errors.New("404")},
+		},
+	})
+
+	assert.Equal(
+		t,
+		[]*linkGroup{
+			{
+				"http://foo.com/bar",
+				[]*linkGroupMessage{{"404", []string{"http://foo.com", "http://foo.com/foo"}, 2}},
+				nil,
+			},
+			{
+				"http://foo.com/baz",
+				[]*linkGroupMessage{{"500", []string{"http://foo.com/foo"}, 1}},
+				nil,
+			},
+		},
+		c.Groups(),
+	)
+	assert.False(t, c.OK())
+}
+
+func TestLinkGroupCollectorGroupErrors(t *testing.T) {
+	c := newLinkGroupCollector(0)
+
+	c.Add(&pageResult{
+		URL:              "http://foo.com",
+		ErrorLinkResults: []*errorLinkResult{{URL: "http://foo.com/bar", Error: errors.New("404")}},
+	})
+	c.Add(&pageResult{
+		URL:              "http://foo.com/foo",
+		ErrorLinkResults: []*errorLinkResult{{URL: "http://foo.com/bar", Error: errors.New("timeout")}},
+	})
+
+	gs := c.Groups()
+
+	assert.Equal(t, 1, len(gs))
+	assert.Equal(t, 2, len(gs[0].Errors))
+}
+
+func TestLinkGroupCollectorGroupWarnings(t *testing.T) {
+	c := newLinkGroupCollector(0)
+
+	c.Add(&pageResult{
+		URL:      "http://foo.com",
+		Warnings: []string{"duplicate id #foo"},
+		WarningLinkResults: []*warningLinkResult{
+			{successLinkResult{URL: "http://foo.com/bar"}, []string{"slow response (2s)"}},
+		},
+		ErrorLinkResults: []*errorLinkResult{{URL: "http://foo.com/bar", Error: errors.New("404")}},
+	})
+	c.Add(&pageResult{
+		URL: "http://foo.com/foo",
+		WarningLinkResults: []*warningLinkResult{
+			{successLinkResult{URL: "http://foo.com/bar"}, []string{"slow response (2s)"}},
+		},
+	})
+
+	assert.Equal(
+		t,
+		[]*linkGroup{
+			{
+				"http://foo.com",
+				nil,
+				[]*linkGroupMessage{{"duplicate id #foo", []string{"http://foo.com"}, 1}},
+			},
+			{
+				"http://foo.com/bar",
+				[]*linkGroupMessage{{"404", []string{"http://foo.com"}, 1}},
+				[]*linkGroupMessage{{"slow response (2s)", []string{"http://foo.com", "http://foo.com/foo"}, 2}},
+			},
+		},
+		c.Groups(),
+	)
+}
+
+func TestLinkGroupCollectorLimitReferrers(t *testing.T) {
+	c := newLinkGroupCollector(1)
+
+	for _, s := range []string{"http://foo.com", "http://foo.com/foo", "http://foo.com/baz"} {
+		c.Add(&pageResult{URL: s, ErrorLinkResults: []*errorLinkResult{{URL: "http://foo.com/bar", Error: errors.New("404")}}})
+	}
+
+	e := c.Groups()[0].Errors[0]
+
+	assert.Equal(t, []string{"http://foo.com"}, e.Referrers)
+	assert.Equal(t, 3, e.ReferrerCount)
+}
+
+func TestLinkGroupCollectorOK(t *testing.T) {
+	c := newLinkGroupCollector(0)
+
+	c.Add(&pageResult{URL: "http://foo.com", SuccessLinkResults: []*successLinkResult{{URL: "http://foo.com/foo"}}})
+
+	assert.Empty(t, c.Groups())
+	assert.True(t, c.OK())
+}
@@ -0,0 +1,42 @@
+package main
+
+import "fmt"
+
+// linkSource is a location of a link in a page.
+type linkSource struct {
+	Line      int
+	Column    int
+	Element   string
+	Attribute string
+	// Text is visible text of a link or alternative text of an image.
+	Text string
+	// Subresource is true if a link is loaded as a part of a page rather than navigated to.
+	Subresource bool
+}
+
+func (s *linkSource) String() string {
+	t := s.Element
+
+	if s.Attribute != "" {
+		t += fmt.Sprintf("[%v]", s.Attribute)
+	}
+
+	if s.Line != 0 {
+		t = fmt.Sprintf("%v:%v %v", s.Line, s.Column, t)
+	}
+
+	if s.Text != "" {
+		t += fmt.Sprintf(" %q", s.Text)
+	}
+
+	return t
+}
+
+// linkSourceLine returns a line of a link source or 0 if unknown.
+func linkSourceLine(s *linkSource) int {
+	if s == nil {
+		return 0
+	}
+
+	return s.Line
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkSourceString(t *testing.T) {
+	assert.Equal(t, "3:5 a[href]", (&linkSource{Line: 3, Column: 5, Element: "a", Attribute: "href"}).String())
+}
+
+func TestLinkSourceStringWithoutPosition(t *testing.T) {
+	assert.Equal(t, "img[src]", (&linkSource{Element: "img", Attribute: "src"}).String())
+}
+
+func TestLinkSourceLine(t *testing.T) {
+	assert.Equal(t, 3, linkSourceLine(&linkSource{Line: 3}))
+	assert.Equal(t, 0, linkSourceLine(nil))
+}
+
+func TestLinkSourceStringWithText(t *testing.T) {
+	assert.Equal(t, `3:5 a[href] "foo"`, (&linkSource{Line: 3, Column: 5, Element: "a", Attribute: "href", Text: "foo"}).String())
+}
+
+func TestLinkSourceStringWithoutAttribute(t *testing.T) {
+	assert.Equal(t, "3:5 style", (&linkSource{Line: 3, Column: 5, Element: "style"}).String())
+}
@@ -12,6 +12,7 @@ func main() {
 		colorable.NewColorableStdout(),
 		os.Stderr,
 		isatty.IsTerminal(os.Stdout.Fd()),
+		isatty.IsTerminal(os.Stderr.Fd()),
 		newFasthttpHttpClientFactory(),
 	).Run(os.Args[1:])
 
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+)
+
+const htmlWhitespaces = " \t\n\f\r"
+
+// parseMetaRefresh parses a content attribute of a `<meta http-equiv="refresh">` element and returns its URL.
+// https://html.spec.whatwg.org/multipage/semantics.html#shared-declarative-refresh-steps
+func parseMetaRefresh(s string) (string, bool) {
+	s = strings.TrimLeft(s, htmlWhitespaces)
+	t := strings.TrimLeft(s, "0123456789.")
+
+	if len(t) == len(s) {
+		return "", false
+	}
+
+	if t != "" && !strings.ContainsRune(";,"+htmlWhitespaces, rune(t[0])) {
+		return "", false
+	}
+
+	s = strings.TrimLeft(t, htmlWhitespaces)
+
+	if s != "" && (s[0] == ';' || s[0] == ',') {
+		s = strings.TrimLeft(s[1:], htmlWhitespaces)
+	}
+
+	if len(s) >= 3 && strings.EqualFold(s[:3], "url") {
+		if t := strings.TrimLeft(s[3:], htmlWhitespaces); strings.HasPrefix(t, "=") {
+			s = strings.TrimLeft(t[1:], htmlWhitespaces)
+		}
+	}
+
+	if s != "" && (s[0] == '"' || s[0] == '\'') {
+		if i := strings.IndexByte(s[1:], s[0]); i >= 0 {
+			s = s[1 : i+1]
+		} else {
+			s = s[1:]
+		}
+	}
+
+	s = strings.TrimSpace(s)
+
+	return s, s != ""
+}
+
+// isMetaRefresh returns true if a content attribute of a meta element is interpreted as refresh.
+func isMetaRefresh(httpEquiv string) bool {
+	return strings.EqualFold(strings.TrimSpace(httpEquiv), "refresh")
+}
+
+// metaRefreshTarget returns a URL without a fragment which a page is refreshed to.
+// It returns nil if a page is not refreshed or refreshed to itself.
+func metaRefreshTarget(p page) *url.URL {
+	if p == nil || p.MetaRefreshURL() == nil {
+		return nil
+	}
+
+	u := *p.MetaRefreshURL()
+	u.Fragment = ""
+
+	if equalURLs(&u, p.URL()) {
+		return nil
+	}
+
+	return &u
+}
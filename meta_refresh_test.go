@@ -0,0 +1,73 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetaRefresh(t *testing.T) {
+	for _, c := range []struct {
+		content string
+		url     string
+		ok      bool
+	}{
+		{"0; url=/foo", "/foo", true},
+		{"0;URL=/foo", "/foo", true},
+		{" 5 ; url = /foo ", "/foo", true},
+		{"0, /foo", "/foo", true},
+		{"0 /foo", "/foo", true},
+		{"0.5; url=/foo", "/foo", true},
+		{`0; url="/foo"`, "/foo", true},
+		{`0; url='/foo' bar`, "/foo", true},
+		{`0; url='/foo`, "/foo", true},
+		{"0", "", false},
+		{"0;", "", false},
+		{"5; url=", "", false},
+		{"url=/foo", "", false},
+		{"0x; url=/foo", "", false},
+	} {
+		s, ok := parseMetaRefresh(c.content)
+
+		assert.Equal(t, c.ok, ok, c.content)
+		assert.Equal(t, c.url, s, c.content)
+	}
+}
+
+func TestIsMetaRefresh(t *testing.T) {
+	assert.True(t, isMetaRefresh("refresh"))
+	assert.True(t, isMetaRefresh(" Refresh "))
+	assert.False(t, isMetaRefresh("content-type"))
+}
+
+func TestMetaRefreshTarget(t *testing.T) {
+	u := parseURL(t, "http://foo.com/")
+
+	for _, c := range []struct {
+		refresh string
+		target  string
+	}{
+		{"", ""},
+		{"http://foo.com/bar#baz", "http://foo.com/bar"},
+		{"http://foo.com/", ""},
+		{"http://foo.com", ""},
+		{"http://foo.com/#bar", ""},
+	} {
+		r := (*url.URL)(nil)
+
+		if c.refresh != "" {
+			r = parseURL(t, c.refresh)
+		}
+
+		v := metaRefreshTarget(newHtmlPage(u, nil, "", nil, nil, nil, r, nil, nil))
+
+		if c.target == "" {
+			assert.Nil(t, v, c.refresh)
+		} else {
+			assert.Equal(t, c.target, v.String(), c.refresh)
+		}
+	}
+
+	assert.Nil(t, metaRefreshTarget(nil))
+}
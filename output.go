@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var outputFormats = map[string]struct{}{
+	"text":               {},
+	"json":               {},
+	"junit":              {},
+	"csv":                {},
+	"github":             {},
+	"gitlab-codequality": {},
+	"tap":                {},
+}
+
+type output struct {
+	Format string
+	Path   string
+}
+
+func parseOutput(s string) (*output, error) {
+	i := strings.IndexRune(s, '=')
+
+	if i < 0 {
+		return nil, errors.New("invalid output format")
+	}
+
+	f, p := s[:i], s[i+1:]
+
+	if _, ok := outputFormats[f]; !ok {
+		return nil, fmt.Errorf("invalid output format: %v", f)
+	} else if p == "" {
+		return nil, errors.New("output path not specified")
+	}
+
+	return &output{f, p}, nil
+}
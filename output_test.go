@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOutput(t *testing.T) {
+	o, err := parseOutput("junit=foo/report.xml")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &output{"junit", "foo/report.xml"}, o)
+}
+
+func TestParseOutputWithEqualSignInPath(t *testing.T) {
+	o, err := parseOutput("json=a=b.json")
+
+	assert.Nil(t, err)
+	assert.Equal(t, &output{"json", "a=b.json"}, o)
+}
+
+func TestParseOutputError(t *testing.T) {
+	for _, s := range []string{"", "junit", "junit=", "foo=report.xml"} {
+		_, err := parseOutput(s)
+		assert.NotNil(t, err)
+	}
+}
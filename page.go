@@ -7,5 +7,13 @@ import (
 type page interface {
 	URL() *url.URL
 	Fragments() map[string]struct{}
+	// Text returns normalized rendered text of a page used to match text fragments.
+	Text() string
 	Links() map[string]error
+	// LinkSources returns locations of links in a page. They can be missing.
+	LinkSources() map[string]*linkSource
+	// Warnings returns problems of a page itself rather than its links.
+	Warnings() []string
+	// MetaRefreshURL returns a URL of a meta refresh or nil if there is none.
+	MetaRefreshURL() *url.URL
 }
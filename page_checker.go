@@ -1,6 +1,14 @@
 package main
 
-import "sync"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 type pageChecker struct {
 	fetcher       *linkFetcher
@@ -8,17 +16,19 @@ type pageChecker struct {
 	daemonManager *daemonManager
 	results       chan *pageResult
 	donePages     concurrentStringSet
-	onePageOnly   bool
+	options       pageCheckerOptions
+	checkedPages  atomic.Int64
+	errors        atomic.Int64
 }
 
-func newPageChecker(f *linkFetcher, v *linkValidator, onePageOnly bool) *pageChecker {
+func newPageChecker(f *linkFetcher, v *linkValidator, o pageCheckerOptions) *pageChecker {
 	return &pageChecker{
-		f,
-		v,
-		newDaemonManager(concurrency),
-		make(chan *pageResult, concurrency),
-		newConcurrentStringSet(),
-		onePageOnly,
+		fetcher:       f,
+		linkValidator: v,
+		daemonManager: newDaemonManager(concurrency),
+		results:       make(chan *pageResult, concurrency),
+		donePages:     newConcurrentStringSet(),
+		options:       o,
 	}
 }
 
@@ -35,14 +45,37 @@ func (c *pageChecker) Check(page page) {
 
 func (c *pageChecker) checkPage(p page) {
 	us := p.Links()
+	srcs := p.LinkSources()
 
 	sc := make(chan *successLinkResult, len(us))
+	wc := make(chan *warningLinkResult, len(us))
 	ec := make(chan *errorLinkResult, len(us))
 	w := sync.WaitGroup{}
+	sws := [][]string(nil)
+
+	// SEO links are checked concurrently with the other links.
+	if h, ok := p.(*htmlPage); ok && c.options.CheckSEO {
+		as := h.HreflangAlternates()
+		sws = make([][]string, len(as)+1)
+
+		w.Add(len(sws))
+
+		go func() {
+			defer w.Done()
+			sws[0] = c.checkCanonicalURL(h)
+		}()
+
+		for i, a := range as {
+			go func(i int, a *hreflangAlternate) {
+				defer w.Done()
+				sws[i+1] = c.checkHreflangAlternate(h, a)
+			}(i, a)
+		}
+	}
 
 	for u, err := range us {
 		if err != nil {
-			ec <- &errorLinkResult{u, err}
+			ec <- &errorLinkResult{URL: u, Error: err, Source: srcs[u]}
 			continue
 		}
 
@@ -51,16 +84,58 @@ func (c *pageChecker) checkPage(p page) {
 		go func(u string) {
 			defer w.Done()
 
-			status, p, err := c.fetcher.Fetch(u)
+			insecure := c.options.CheckInsecureLinks && isInsecureLink(p.URL(), u)
+
+			if insecure && srcs[u] != nil && srcs[u].Subresource {
+				ec <- &errorLinkResult{
+					URL:    u,
+					Error:  fmt.Errorf("%w%v", errMixedContent, c.suggestHTTPS(u)),
+					Source: srcs[u],
+				}
+				return
+			}
+
+			r, err := c.fetcher.Fetch(u)
 
 			if err == nil {
-				sc <- &successLinkResult{u, status}
+				err = c.checkRedirects(u, r)
+			}
+
+			if err != nil {
+				ec <- &errorLinkResult{URL: u, Error: err, Source: srcs[u]}
+				return
+			}
+
+			s := successLinkResult{
+				URL:             u,
+				StatusCode:      r.StatusCode,
+				ContentType:     r.ContentType,
+				BodySize:        r.BodySize,
+				TimeToFirstByte: r.TimeToFirstByte,
+				Duration:        r.Duration,
+				RedirectURL:     r.RedirectURL,
+				Redirects:       r.Redirects,
+				Source:          srcs[u],
+			}
+
+			ws := c.checkWarnings(r)
+
+			if c.options.CheckMetaRefreshes {
+				ws = append(ws, c.checkMetaRefresh(r)...)
+			}
+
+			if insecure {
+				ws = append(ws, "insecure link"+c.suggestHTTPS(u))
+			}
+
+			if len(ws) != 0 {
+				wc <- &warningLinkResult{s, ws}
 			} else {
-				ec <- &errorLinkResult{u, err}
+				sc <- &s
 			}
 
-			if !c.onePageOnly && p != nil && c.linkValidator.Validate(p.URL()) {
-				c.addPage(p)
+			if !c.options.OnePageOnly && r.Page != nil && c.linkValidator.Validate(r.Page.URL()) {
+				c.addPage(r.Page)
 			}
 		}(u)
 	}
@@ -68,6 +143,7 @@ func (c *pageChecker) checkPage(p page) {
 	w.Wait()
 
 	close(sc)
+	close(wc)
 	close(ec)
 
 	ss := make([]*successLinkResult, 0, len(sc))
@@ -76,17 +152,178 @@ func (c *pageChecker) checkPage(p page) {
 		ss = append(ss, s)
 	}
 
+	ws := make([]*warningLinkResult, 0, len(wc))
+
+	for w := range wc {
+		ws = append(ws, w)
+	}
+
 	es := make([]*errorLinkResult, 0, len(ec))
 
 	for e := range ec {
 		es = append(es, e)
 	}
 
-	c.results <- &pageResult{p.URL().String(), ss, es}
+	c.checkedPages.Add(1)
+	c.errors.Add(int64(len(es)))
+
+	c.results <- &pageResult{
+		URL:                p.URL().String(),
+		Warnings:           slices.Concat(append([][]string{p.Warnings()}, sws...)...),
+		SuccessLinkResults: ss,
+		WarningLinkResults: ws,
+		ErrorLinkResults:   es,
+	}
+}
+
+// CheckedPages returns a number of pages checked so far.
+func (c *pageChecker) CheckedPages() int {
+	return int(c.checkedPages.Load())
+}
+
+// PendingPages returns a number of pages queued or being checked.
+func (c *pageChecker) PendingPages() int {
+	return c.daemonManager.Count()
+}
+
+// Errors returns a number of errors found so far.
+func (c *pageChecker) Errors() int {
+	return int(c.errors.Load())
+}
+
+func (c *pageChecker) checkWarnings(r *fetchResult) []string {
+	ws := []string(nil)
+
+	if t := c.options.SlowThreshold; t > 0 && r.Duration > t {
+		ws = append(ws, fmt.Sprintf("slow response (%v)", r.Duration.Round(time.Millisecond)))
+	}
+
+	if c.options.WarnPermanentRedirects && r.RedirectURL != "" {
+		for _, h := range r.Redirects {
+			if isPermanentRedirect(h.StatusCode) {
+				ws = append(ws, fmt.Sprintf("permanent redirect (%v); update this link to %v", h.StatusCode, r.RedirectURL))
+				break
+			}
+		}
+	}
+
+	return ws
+}
+
+// checkMetaRefresh warns about meta refreshes to broken or redirected pages.
+func (c *pageChecker) checkMetaRefresh(r *fetchResult) []string {
+	u := metaRefreshTarget(r.Page)
+
+	if u == nil || !c.options.LinkFilterer.IsValid(u) {
+		return nil
+	}
+
+	s, err := c.fetcher.Fetch(u.String())
+
+	if err != nil {
+		return []string{fmt.Sprintf("broken meta refresh to %v (%v)", u, err)}
+	} else if s.RedirectURL != "" || metaRefreshTarget(s.Page) != nil {
+		return []string{fmt.Sprintf("meta refresh to %v redirects again", u)}
+	}
+
+	return nil
+}
+
+// checkCanonicalURL checks if a canonical link of a page points to a self-canonical page without redirects.
+func (c *pageChecker) checkCanonicalURL(p *htmlPage) []string {
+	u := p.CanonicalURL()
+
+	if u == nil || equalURLs(u, p.URL()) || !c.options.LinkFilterer.IsValid(u) {
+		return nil
+	}
+
+	r, err := c.fetcher.Fetch(u.String())
+
+	if err != nil {
+		return []string{fmt.Sprintf("broken canonical link to %v (%v)", u, err)}
+	} else if r.RedirectURL != "" {
+		return []string{fmt.Sprintf("canonical link to %v redirects to %v", u, r.RedirectURL)}
+	} else if r.StatusCode != http.StatusOK {
+		return []string{fmt.Sprintf("canonical link to %v returns status %v", u, r.StatusCode)}
+	} else if q, ok := r.Page.(*htmlPage); ok && q.CanonicalURL() != nil && !equalURLs(q.CanonicalURL(), q.URL()) {
+		return []string{fmt.Sprintf("canonical link to %v is not self-canonical", u)}
+	}
+
+	return nil
+}
+
+// checkHreflangAlternate checks if a hreflang alternate of a page links back to it.
+func (c *pageChecker) checkHreflangAlternate(p *htmlPage, a *hreflangAlternate) []string {
+	if equalURLs(a.URL, p.URL()) || !c.options.LinkFilterer.IsValid(a.URL) {
+		return nil
+	}
+
+	r, err := c.fetcher.Fetch(a.URL.String())
+
+	if err != nil {
+		return []string{fmt.Sprintf("broken hreflang %v alternate %v (%v)", a.Language, a.URL, err)}
+	}
+
+	q, ok := r.Page.(*htmlPage)
+
+	if !ok || !slices.ContainsFunc(q.HreflangAlternates(), func(b *hreflangAlternate) bool {
+		return equalURLs(b.URL, p.URL())
+	}) {
+		return []string{fmt.Sprintf("hreflang %v alternate %v does not link back", a.Language, a.URL)}
+	}
+
+	return nil
+}
+
+// suggestHTTPS suggests an HTTPS version of a link if it is available.
+func (c *pageChecker) suggestHTTPS(u string) string {
+	if !c.options.ProbeHTTPS {
+		return ""
+	}
+
+	v, err := upgradeToHTTPS(u)
+	if err != nil {
+		return ""
+	} else if _, err := c.fetcher.Fetch(v); err != nil {
+		return ""
+	}
+
+	return "; upgrade to " + v
+}
+
+func (c *pageChecker) checkRedirects(s string, r *fetchResult) error {
+	if !c.options.FailOnCrossHostRedirects || r.RedirectURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+
+	v, err := url.Parse(r.RedirectURL)
+	if err != nil {
+		return err
+	} else if u.Host != v.Host {
+		return fmt.Errorf("redirected to another host (%v)", r.RedirectURL)
+	}
+
+	return nil
 }
 
 func (c *pageChecker) addPage(p page) {
-	if !c.donePages.Add(p.URL().String()) {
+	if !c.donePages.Add(c.pageKey(p)) {
 		c.daemonManager.Add(func() { c.checkPage(p) })
 	}
 }
+
+// pageKey returns a key to deduplicate crawled pages.
+func (c *pageChecker) pageKey(p page) string {
+	if h, ok := p.(*htmlPage); ok && c.options.DeduplicateCanonical {
+		if u := h.CanonicalURL(); u != nil && u.Host == p.URL().Host {
+			return c.options.URLNormalizer.Normalize(u).String()
+		}
+	}
+
+	return c.options.URLNormalizer.Normalize(p.URL()).String()
+}
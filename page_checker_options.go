@@ -0,0 +1,24 @@
+package main
+
+import "time"
+
+type pageCheckerOptions struct {
+	OnePageOnly bool
+	// SlowThreshold is a duration above which responses are warned. It is disabled if zero.
+	SlowThreshold            time.Duration
+	WarnPermanentRedirects   bool
+	FailOnCrossHostRedirects bool
+	CheckInsecureLinks       bool
+	// ProbeHTTPS suggests HTTPS versions of insecure links if they are available.
+	ProbeHTTPS bool
+	// CheckMetaRefreshes warns about meta refreshes of linked pages to broken or redirected pages.
+	CheckMetaRefreshes bool
+	// LinkFilterer filters links found in pages other than ones checked directly.
+	LinkFilterer linkFilterer
+	// CheckSEO checks canonical links and hreflang alternates of HTML pages.
+	CheckSEO bool
+	// DeduplicateCanonical crawls pages with the same same-host canonical URL only once.
+	DeduplicateCanonical bool
+	// URLNormalizer normalizes URLs of pages to crawl them only once.
+	URLNormalizer *urlNormalizer
+}
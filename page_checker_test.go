@@ -3,20 +3,26 @@ package main
 import (
 	"errors"
 	"net/url"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func newTestPageChecker(c *fakeHttpClient) *pageChecker {
+	return newTestPageCheckerWithOptions(c, pageCheckerOptions{})
+}
+
+func newTestPageCheckerWithOptions(c *fakeHttpClient, o pageCheckerOptions) *pageChecker {
 	return newPageChecker(
 		newLinkFetcher(
 			c,
-			[]pageParser{newHtmlPageParser(newTestLinkFinder())},
+			[]pageParser{newHtmlPageParser(newTestLinkFinder()), newTestCSSPageParser()},
 			linkFetcherOptions{},
 		),
 		newLinkValidator("foo.com", nil, nil),
-		false,
+		o,
 	)
 }
 
@@ -24,7 +30,7 @@ func newTestPage(t *testing.T, fragments map[string]struct{}, links map[string]e
 	u, err := url.Parse("http://foo.com")
 	assert.Nil(t, err)
 
-	return newHtmlPage(u, fragments, links)
+	return newHtmlPage(u, fragments, "", links, nil, nil, nil, nil, nil)
 }
 
 func TestPageCheckerCheckOnePage(t *testing.T) {
@@ -77,6 +83,41 @@ func TestPageCheckerCheckTwoPages(t *testing.T) {
 	assert.Equal(t, 2, i)
 }
 
+func TestPageCheckerCheckStylesheets(t *testing.T) {
+	c := newTestPageChecker(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				switch u.String() {
+				case "http://foo.com/style.css":
+					return newFakeCSSResponse(u.String(), `body { background: url(bg.png); }`), nil
+				case "http://bar.com/style.css":
+					return newFakeCSSResponse(u.String(), `body { background: url(bg.png); }`), nil
+				}
+
+				return nil, errors.New("")
+			},
+		),
+	)
+
+	go c.Check(
+		newTestPage(
+			t,
+			nil,
+			map[string]error{"http://foo.com/style.css": nil, "http://bar.com/style.css": nil},
+		),
+	)
+
+	rs := map[string]*pageResult{}
+
+	for r := range c.Results() {
+		rs[r.URL] = r
+	}
+
+	assert.Equal(t, 2, len(rs))
+	assert.True(t, rs["http://foo.com"].OK())
+	assert.Equal(t, "http://foo.com/bg.png", rs["http://foo.com/style.css"].ErrorLinkResults[0].URL)
+}
+
 func TestPageCheckerFailToCheckPage(t *testing.T) {
 	c := newTestPageChecker(
 		newFakeHttpClient(
@@ -112,3 +153,617 @@ func TestPageCheckerDoNotCheckSamePageTwice(t *testing.T) {
 
 	assert.Equal(t, 1, i)
 }
+
+func TestPageCheckerWarnSlowLinks(t *testing.T) {
+	c := newTestPageCheckerWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				r := newFakeHtmlResponse(u.String(), "")
+
+				if u.String() == "http://foo.com/slow" {
+					r.duration = 2 * time.Second
+				}
+
+				return r, nil
+			},
+		),
+		pageCheckerOptions{OnePageOnly: true, SlowThreshold: time.Second},
+	)
+
+	go c.Check(
+		newTestPage(
+			t,
+			nil,
+			map[string]error{"http://foo.com/fast": nil, "http://foo.com/slow": nil},
+		),
+	)
+
+	r := <-c.Results()
+
+	assert.True(t, r.OK())
+	assert.True(t, r.Warned())
+
+	assert.Equal(t, 1, len(r.SuccessLinkResults))
+	assert.Equal(t, "http://foo.com/fast", r.SuccessLinkResults[0].URL)
+	assert.Equal(t, 1, len(r.WarningLinkResults))
+	assert.Equal(t, "http://foo.com/slow", r.WarningLinkResults[0].URL)
+	assert.Equal(t, []string{"slow response (2s)"}, r.WarningLinkResults[0].Warnings)
+}
+
+func TestPageCheckerCountPagesAndErrors(t *testing.T) {
+	c := newTestPageChecker(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return nil, errors.New("")
+			},
+		),
+	)
+
+	go c.Check(
+		newTestPage(t, nil, map[string]error{"http://foo.com/foo": nil}),
+	)
+
+	for range c.Results() {
+	}
+
+	assert.Equal(t, 1, c.CheckedPages())
+	assert.Equal(t, 0, c.PendingPages())
+	assert.Equal(t, 1, c.Errors())
+}
+
+func TestPageCheckerSetLinkSources(t *testing.T) {
+	c := newTestPageChecker(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return nil, errors.New("")
+			},
+		),
+	)
+
+	u, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	s := &linkSource{Line: 42, Column: 3, Element: "a", Attribute: "href"}
+
+	go c.Check(
+		newHtmlPage(
+			u,
+			nil,
+			"",
+			map[string]error{"http://foo.com/foo": nil},
+			map[string]*linkSource{"http://foo.com/foo": s},
+			nil,
+			nil,
+			nil,
+			nil,
+		),
+	)
+
+	assert.Equal(t, s, (<-c.Results()).ErrorLinkResults[0].Source)
+}
+
+func newRedirectedFakeHtmlResponse(hs []*redirectHop) *fakeHttpResponse {
+	r := newFakeHtmlResponse(hs[len(hs)-1].Location, "")
+	r.redirects = hs
+
+	return r
+}
+
+func TestPageCheckerWarnPermanentRedirects(t *testing.T) {
+	c := newTestPageCheckerWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				switch u.String() {
+				case "http://foo.com/permanent":
+					return newRedirectedFakeHtmlResponse([]*redirectHop{{u.String(), 301, "http://foo.com/new"}}), nil
+				case "http://foo.com/temporary":
+					return newRedirectedFakeHtmlResponse([]*redirectHop{{u.String(), 302, "http://foo.com/new"}}), nil
+				}
+
+				return newFakeHtmlResponse(u.String(), ""), nil
+			},
+		),
+		pageCheckerOptions{OnePageOnly: true, WarnPermanentRedirects: true},
+	)
+
+	go c.Check(
+		newTestPage(
+			t,
+			nil,
+			map[string]error{"http://foo.com/permanent": nil, "http://foo.com/temporary": nil},
+		),
+	)
+
+	r := <-c.Results()
+
+	assert.True(t, r.OK())
+	assert.Equal(t, 1, len(r.SuccessLinkResults))
+	assert.Equal(t, "http://foo.com/temporary", r.SuccessLinkResults[0].URL)
+	assert.Equal(t, "http://foo.com/new", r.SuccessLinkResults[0].RedirectURL)
+	assert.Equal(t, 1, len(r.WarningLinkResults))
+
+	l := r.WarningLinkResults[0]
+
+	assert.Equal(t, "http://foo.com/permanent", l.URL)
+	assert.Equal(t, "http://foo.com/new", l.RedirectURL)
+	assert.Equal(t, 1, len(l.Redirects))
+	assert.Equal(
+		t,
+		[]string{"permanent redirect (301); update this link to http://foo.com/new"},
+		l.Warnings,
+	)
+}
+
+func TestPageCheckerFailOnCrossHostRedirects(t *testing.T) {
+	c := newTestPageCheckerWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				switch u.String() {
+				case "http://foo.com/foo":
+					return newRedirectedFakeHtmlResponse([]*redirectHop{{u.String(), 302, "http://bar.com/foo"}}), nil
+				case "http://foo.com/bar":
+					return newRedirectedFakeHtmlResponse([]*redirectHop{{u.String(), 302, "http://foo.com/baz"}}), nil
+				}
+
+				return newFakeHtmlResponse(u.String(), ""), nil
+			},
+		),
+		pageCheckerOptions{OnePageOnly: true, FailOnCrossHostRedirects: true},
+	)
+
+	go c.Check(
+		newTestPage(
+			t,
+			nil,
+			map[string]error{"http://foo.com/foo": nil, "http://foo.com/bar": nil},
+		),
+	)
+
+	r := <-c.Results()
+
+	assert.Equal(t, 1, len(r.SuccessLinkResults))
+	assert.Equal(t, "http://foo.com/bar", r.SuccessLinkResults[0].URL)
+	assert.Equal(t, 1, len(r.ErrorLinkResults))
+	assert.Equal(t, "redirected to another host (http://bar.com/foo)", r.ErrorLinkResults[0].Error.Error())
+}
+
+func newTestSecurePage(links map[string]error, sources map[string]*linkSource) page {
+	u, err := url.Parse("https://foo.com")
+	if err != nil {
+		panic(err)
+	}
+
+	return newHtmlPage(u, nil, "", links, sources, nil, nil, nil, nil)
+}
+
+func TestPageCheckerCheckInsecureLinks(t *testing.T) {
+	c := newTestPageCheckerWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeHtmlResponse(u.String(), ""), nil
+			},
+		),
+		pageCheckerOptions{OnePageOnly: true, CheckInsecureLinks: true},
+	)
+
+	go c.Check(
+		newTestSecurePage(
+			map[string]error{
+				"http://foo.com/foo.js": nil,
+				"http://foo.com/foo":    nil,
+				"https://foo.com/bar":   nil,
+			},
+			map[string]*linkSource{
+				"http://foo.com/foo.js": {Element: "script", Attribute: "src", Subresource: true},
+				"http://foo.com/foo":    {Element: "a", Attribute: "href"},
+			},
+		),
+	)
+
+	r := <-c.Results()
+
+	assert.Equal(t, 1, len(r.SuccessLinkResults))
+	assert.Equal(t, "https://foo.com/bar", r.SuccessLinkResults[0].URL)
+	assert.Equal(t, 1, len(r.WarningLinkResults))
+	assert.Equal(t, "http://foo.com/foo", r.WarningLinkResults[0].URL)
+	assert.Equal(t, []string{"insecure link"}, r.WarningLinkResults[0].Warnings)
+	assert.Equal(t, 1, len(r.ErrorLinkResults))
+	assert.Equal(t, "http://foo.com/foo.js", r.ErrorLinkResults[0].URL)
+	assert.ErrorIs(t, r.ErrorLinkResults[0].Error, errMixedContent)
+}
+
+func TestPageCheckerCheckInsecureLinksInStylesheet(t *testing.T) {
+	c := newTestPageCheckerWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeHtmlResponse(u.String(), ""), nil
+			},
+		),
+		pageCheckerOptions{OnePageOnly: true, CheckInsecureLinks: true},
+	)
+
+	p, err := newTestCSSPageParser().Parse(
+		parseURL(t, "https://foo.com/style.css"),
+		"text/css",
+		[]byte(`@font-face { src: url(http://foo.com/foo.woff); }`),
+	)
+	assert.Nil(t, err)
+
+	go c.Check(p)
+
+	r := <-c.Results()
+
+	assert.Equal(t, 1, len(r.ErrorLinkResults))
+	assert.ErrorIs(t, r.ErrorLinkResults[0].Error, errMixedContent)
+}
+
+func TestPageCheckerProbeHTTPS(t *testing.T) {
+	c := newTestPageCheckerWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				if u.String() == "https://foo.com/bar" {
+					return nil, errors.New("")
+				}
+
+				return newFakeHtmlResponse(u.String(), ""), nil
+			},
+		),
+		pageCheckerOptions{OnePageOnly: true, CheckInsecureLinks: true, ProbeHTTPS: true},
+	)
+
+	go c.Check(
+		newTestSecurePage(
+			map[string]error{
+				"http://foo.com/foo.js": nil,
+				"http://foo.com/foo":    nil,
+				"http://foo.com/bar":    nil,
+			},
+			map[string]*linkSource{
+				"http://foo.com/foo.js": {Element: "script", Attribute: "src", Subresource: true},
+			},
+		),
+	)
+
+	r := <-c.Results()
+
+	assert.Equal(
+		t,
+		"insecure subresource on HTTPS page; upgrade to https://foo.com/foo.js",
+		r.ErrorLinkResults[0].Error.Error(),
+	)
+
+	ws := map[string][]string{}
+
+	for _, l := range r.WarningLinkResults {
+		ws[l.URL] = l.Warnings
+	}
+
+	assert.Equal(
+		t,
+		map[string][]string{
+			"http://foo.com/foo": {"insecure link; upgrade to https://foo.com/foo"},
+			"http://foo.com/bar": {"insecure link"},
+		},
+		ws,
+	)
+}
+
+func TestPageCheckerReportPageWarnings(t *testing.T) {
+	c := newTestPageChecker(newFakeHttpClient(nil))
+
+	u, err := url.Parse("http://foo.com")
+	assert.Nil(t, err)
+
+	go c.Check(newHtmlPage(u, nil, "", nil, nil, []string{"duplicate id #foo"}, nil, nil, nil))
+
+	r := <-c.Results()
+
+	assert.True(t, r.OK())
+	assert.True(t, r.Warned())
+	assert.Equal(t, []string{"duplicate id #foo"}, r.Warnings)
+}
+
+func TestPageCheckerWarnMetaRefreshes(t *testing.T) {
+	c := newTestPageCheckerWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				switch u.Path {
+				case "/foo":
+					return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/bar" />`), nil
+				case "/bar":
+					return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/baz" />`), nil
+				case "/baz":
+					return newFakeHtmlResponse(u.String(), ""), nil
+				case "/qux":
+					return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/quux" />`), nil
+				case "/corge":
+					return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/excluded" />`), nil
+				}
+
+				return nil, errors.New("404")
+			},
+		),
+		pageCheckerOptions{
+			CheckMetaRefreshes: true,
+			LinkFilterer:       newLinkFilterer([]*regexp.Regexp{regexp.MustCompile("excluded")}, nil),
+		},
+	)
+
+	go c.Check(
+		newTestPage(
+			t,
+			nil,
+			map[string]error{
+				"http://foo.com/foo":   nil,
+				"http://foo.com/bar":   nil,
+				"http://foo.com/qux":   nil,
+				"http://foo.com/corge": nil,
+			},
+		),
+	)
+
+	ws := map[string][]string{}
+
+	for r := range c.Results() {
+		for _, r := range r.WarningLinkResults {
+			ws[r.URL] = r.Warnings
+		}
+	}
+
+	assert.Equal(
+		t,
+		map[string][]string{
+			"http://foo.com/foo": {"meta refresh to http://foo.com/bar redirects again"},
+			"http://foo.com/qux": {"broken meta refresh to http://foo.com/quux (404)"},
+		},
+		ws,
+	)
+}
+
+func TestPageCheckerNotWarnMetaRefreshesByDefault(t *testing.T) {
+	c := newTestPageChecker(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				if u.Path == "/foo" {
+					return newFakeHtmlResponse(u.String(), `<meta http-equiv="refresh" content="0; url=/bar" />`), nil
+				}
+
+				return nil, errors.New("404")
+			},
+		),
+	)
+
+	go c.Check(newTestPage(t, nil, map[string]error{"http://foo.com/foo": nil}))
+
+	for r := range c.Results() {
+		assert.Empty(t, r.WarningLinkResults)
+	}
+}
+
+func newTestSEOHttpClient() *fakeHttpClient {
+	return newFakeHttpClient(
+		func(u *url.URL) (*fakeHttpResponse, error) {
+			switch u.Path {
+			case "/self":
+				return newFakeHtmlResponse(u.String(), `<link rel="canonical" href="/self" />`), nil
+			case "/implicit":
+				return newFakeHtmlResponse(u.String(), ""), nil
+			case "/chain":
+				return newFakeHtmlResponse(u.String(), `<link rel="canonical" href="/self" />`), nil
+			case "/redirect":
+				return newFakeHtmlResponse("http://foo.com/self", `<link rel="canonical" href="/self" />`), nil
+			case "/en":
+				return newFakeHtmlResponse(u.String(), `<link rel="alternate" hreflang="ja" href="/" />`), nil
+			case "/fr":
+				return newFakeHtmlResponse(u.String(), `<link rel="alternate" hreflang="en" href="/en" />`), nil
+			}
+
+			return nil, errors.New("404")
+		},
+	)
+}
+
+func checkTestSEOPage(t *testing.T, body string) []string {
+	c := newTestPageCheckerWithOptions(
+		newTestSEOHttpClient(),
+		pageCheckerOptions{
+			OnePageOnly:  true,
+			CheckSEO:     true,
+			LinkFilterer: newLinkFilterer([]*regexp.Regexp{regexp.MustCompile("excluded")}, nil),
+		},
+	)
+
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(parseURL(t, "http://foo.com/"), HTML_MIME_TYPE, []byte(body))
+	assert.Nil(t, err)
+
+	go c.Check(p)
+
+	return (<-c.Results()).Warnings
+}
+
+func TestPageCheckerCheckCanonicalURL(t *testing.T) {
+	for _, c := range []struct {
+		html     string
+		warnings []string
+	}{
+		{``, nil},
+		{`<link rel="canonical" href="/" />`, nil},
+		{`<link rel="canonical" href="/self" />`, nil},
+		{`<link rel="canonical" href="/implicit" />`, nil},
+		{
+			`<link rel="canonical" href="/chain" />`,
+			[]string{"canonical link to http://foo.com/chain is not self-canonical"},
+		},
+		{
+			`<link rel="canonical" href="/redirect" />`,
+			[]string{"canonical link to http://foo.com/redirect redirects to http://foo.com/self"},
+		},
+		{
+			`<link rel="canonical" href="/missing" />`,
+			[]string{"broken canonical link to http://foo.com/missing (404)"},
+		},
+		{`<link rel="canonical" href="/excluded/page.html" />`, nil},
+	} {
+		assert.Equal(t, c.warnings, checkTestSEOPage(t, c.html), c.html)
+	}
+}
+
+func TestPageCheckerCheckHreflangAlternates(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{
+			"hreflang fr alternate http://foo.com/fr does not link back",
+			"broken hreflang de alternate http://foo.com/de (404)",
+		},
+		checkTestSEOPage(
+			t,
+			`
+				<link rel="alternate" hreflang="ja" href="/" />
+				<link rel="alternate" hreflang="en" href="/en" />
+				<link rel="alternate" hreflang="fr" href="/fr" />
+				<link rel="alternate" hreflang="de" href="/de" />
+				<link rel="alternate" hreflang="es" href="/excluded/es" />
+			`,
+		),
+	)
+}
+
+func TestPageCheckerCheckSEOConcurrentlyWithLinks(t *testing.T) {
+	ch := make(chan struct{})
+
+	c := newTestPageCheckerWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				switch u.Path {
+				case "/self":
+					close(ch)
+					return newFakeHtmlResponse(u.String(), `<link rel="canonical" href="/self" />`), nil
+				case "/foo":
+					select {
+					case <-ch:
+						return newFakeHtmlResponse(u.String(), ""), nil
+					case <-time.After(time.Second):
+					}
+				}
+
+				return nil, errors.New("404")
+			},
+		),
+		pageCheckerOptions{OnePageOnly: true, CheckSEO: true},
+	)
+
+	p, err := newHtmlPageParser(
+		newLinkFinder(newTestLinkFilterer(), linkFinderOptions{ExcludedElements: []string{"link"}}),
+	).Parse(
+		parseURL(t, "http://foo.com/"),
+		HTML_MIME_TYPE,
+		[]byte(`<link rel="canonical" href="/self" /><a href="/foo">foo</a>`),
+	)
+	assert.Nil(t, err)
+
+	go c.Check(p)
+
+	r := <-c.Results()
+	assert.Empty(t, r.ErrorLinkResults)
+	assert.Nil(t, r.Warnings)
+}
+
+func TestPageCheckerCheckSEODisabled(t *testing.T) {
+	c := newTestPageChecker(newTestSEOHttpClient())
+
+	p, err := newHtmlPageParser(newTestLinkFinder()).Parse(
+		parseURL(t, "http://foo.com/"),
+		HTML_MIME_TYPE,
+		[]byte(`<link rel="canonical" href="/missing" />`),
+	)
+	assert.Nil(t, err)
+
+	go c.Check(p)
+
+	for r := range c.Results() {
+		assert.Nil(t, r.Warnings)
+	}
+}
+
+func TestPageCheckerDeduplicateCanonical(t *testing.T) {
+	for _, c := range []struct {
+		deduplicate bool
+		pages       int
+	}{
+		{false, 5},
+		{true, 4},
+	} {
+		ch := newTestPageCheckerWithOptions(
+			newFakeHttpClient(
+				func(u *url.URL) (*fakeHttpResponse, error) {
+					switch u.Path {
+					case "/foo":
+						return newFakeHtmlResponse(u.String(), `<link rel="canonical" href="/foo" /><a href="/bar">bar</a>`), nil
+					case "/baz":
+						return newFakeHtmlResponse(u.String(), `<link rel="canonical" href="http://bar.com/foo" />`), nil
+					case "/bar":
+						return newFakeHtmlResponse(u.String(), ""), nil
+					}
+
+					return nil, errors.New("404")
+				},
+			),
+			pageCheckerOptions{DeduplicateCanonical: c.deduplicate},
+		)
+
+		go ch.Check(
+			newTestPage(
+				t,
+				nil,
+				map[string]error{
+					"http://foo.com/foo":       nil,
+					"http://foo.com/foo?utm=1": nil,
+					"http://foo.com/baz":       nil,
+				},
+			),
+		)
+
+		i := 0
+
+		for r := range ch.Results() {
+			i++
+			assert.True(t, r.OK())
+		}
+
+		assert.Equal(t, c.pages, i, c.deduplicate)
+	}
+}
+
+func TestPageCheckerNormalizePageURLs(t *testing.T) {
+	n, err := newURLNormalizer([]string{"utm_*"})
+	assert.Nil(t, err)
+
+	c := newTestPageCheckerWithOptions(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeHtmlResponse(u.String(), ""), nil
+			},
+		),
+		pageCheckerOptions{URLNormalizer: n},
+	)
+
+	go c.Check(
+		newTestPage(
+			t,
+			nil,
+			map[string]error{
+				"http://foo.com/foo":                nil,
+				"http://foo.com/foo?utm_source=bar": nil,
+				"http://Foo.com:80/foo":             nil,
+			},
+		),
+	)
+
+	i := 0
+
+	for range c.Results() {
+		i++
+	}
+
+	assert.Equal(t, 2, i)
+}
@@ -1,21 +1,59 @@
 package main
 
+import (
+	"errors"
+	"time"
+)
+
 type pageResult struct {
-	URL                string
+	URL string
+	// Warnings are problems of a page itself rather than its links.
+	Warnings           []string
 	SuccessLinkResults []*successLinkResult
+	WarningLinkResults []*warningLinkResult
 	ErrorLinkResults   []*errorLinkResult
 }
 
 type successLinkResult struct {
-	URL        string
-	StatusCode int
+	URL             string
+	StatusCode      int
+	ContentType     string
+	BodySize        int
+	TimeToFirstByte time.Duration
+	Duration        time.Duration
+	RedirectURL     string
+	Redirects       []*redirectHop
+	Source          *linkSource
+}
+
+// warningLinkResult is a result of a link which is available but has problems.
+type warningLinkResult struct {
+	successLinkResult
+	Warnings []string
 }
 
 type errorLinkResult struct {
-	URL   string
-	Error error
+	URL    string
+	Error  error
+	Source *linkSource
 }
 
 func (r *pageResult) OK() bool {
 	return len(r.ErrorLinkResults) == 0
 }
+
+// Warned returns true if a page or any links have warnings.
+func (r *pageResult) Warned() bool {
+	return len(r.Warnings) != 0 || len(r.WarningLinkResults) != 0
+}
+
+// StatusCode returns a status code of an error response or 0 if unavailable.
+func (r *errorLinkResult) StatusCode() int {
+	e := &statusCodeError{}
+
+	if errors.As(r.Error, &e) {
+		return e.StatusCode()
+	}
+
+	return 0
+}
@@ -0,0 +1,49 @@
+package main
+
+// fanOutPageResults copies page results from a channel into multiple ones.
+// Every returned channel needs to be drained so that the others are not blocked.
+func fanOutPageResults(rc <-chan *pageResult, n int) []<-chan *pageResult {
+	cs := make([]chan *pageResult, 0, n)
+	rcs := make([]<-chan *pageResult, 0, n)
+
+	for range n {
+		c := make(chan *pageResult, concurrency)
+		cs = append(cs, c)
+		rcs = append(rcs, c)
+	}
+
+	go func() {
+		for r := range rc {
+			for _, c := range cs {
+				c <- r
+			}
+		}
+
+		for _, c := range cs {
+			close(c)
+		}
+	}()
+
+	return rcs
+}
+
+// observePageResults calls a function on each page result passed through.
+func observePageResults(rc <-chan *pageResult, f func(*pageResult)) <-chan *pageResult {
+	oc := make(chan *pageResult, cap(rc))
+
+	go func() {
+		for r := range rc {
+			f(r)
+			oc <- r
+		}
+
+		close(oc)
+	}()
+
+	return oc
+}
+
+func drainPageResults(rc <-chan *pageResult) {
+	for range rc {
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFanOutPageResults(t *testing.T) {
+	c := make(chan *pageResult)
+	rcs := fanOutPageResults(c, 3)
+
+	go func() {
+		for range 100 {
+			c <- &pageResult{URL: "http://foo.com"}
+		}
+
+		close(c)
+	}()
+
+	g := &sync.WaitGroup{}
+
+	for _, rc := range rcs {
+		g.Add(1)
+
+		go func() {
+			defer g.Done()
+
+			i := 0
+
+			for range rc {
+				i++
+			}
+
+			assert.Equal(t, 100, i)
+		}()
+	}
+
+	g.Wait()
+}
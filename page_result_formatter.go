@@ -2,8 +2,11 @@ package main
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/logrusorgru/aurora/v3"
 )
@@ -20,10 +23,15 @@ func newPageResultFormatter(verbose bool, color bool) *pageResultFormatter {
 func (f *pageResultFormatter) Format(r *pageResult) string {
 	ss := []string(nil)
 
+	for _, w := range r.Warnings {
+		ss = append(ss, fmt.Sprint(f.aurora.Yellow(w)))
+	}
+
 	if f.verbose {
 		ss = append(ss, f.formatSuccessLinkResults(r.SuccessLinkResults)...)
 	}
 
+	ss = append(ss, f.formatWarningLinkResults(r.WarningLinkResults)...)
 	ss = append(ss, f.formatErrorLinkResults(r.ErrorLinkResults)...)
 
 	return strings.Join(
@@ -36,7 +44,35 @@ func (f *pageResultFormatter) formatSuccessLinkResults(rs []*successLinkResult)
 	ss := make([]string, 0, len(rs))
 
 	for _, r := range rs {
-		ss = append(ss, fmt.Sprintf("%v", f.aurora.Green(r.StatusCode))+"\t"+r.URL)
+		s := fmt.Sprintf("%v", f.aurora.Green(r.StatusCode)) + "\t" + r.URL + formatLinkSource(r.Source)
+
+		if r.RedirectURL != "" {
+			s += " -> " + r.RedirectURL
+		}
+
+		if r.Duration > 0 {
+			s += fmt.Sprintf(
+				"\t%v (TTFB %v)",
+				r.Duration.Round(time.Millisecond),
+				r.TimeToFirstByte.Round(time.Millisecond),
+			)
+		}
+
+		ss = append(ss, s)
+	}
+
+	sort.Strings(ss)
+
+	return ss
+}
+
+func (f *pageResultFormatter) formatWarningLinkResults(rs []*warningLinkResult) []string {
+	ss := []string(nil)
+
+	for _, r := range rs {
+		for _, w := range r.Warnings {
+			ss = append(ss, fmt.Sprintf("%v", f.aurora.Yellow(w))+"\t"+r.URL+formatLinkSource(r.Source))
+		}
 	}
 
 	sort.Strings(ss)
@@ -48,7 +84,7 @@ func (f *pageResultFormatter) formatErrorLinkResults(rs []*errorLinkResult) []st
 	ss := make([]string, 0, len(rs))
 
 	for _, r := range rs {
-		ss = append(ss, fmt.Sprintf("%v", f.aurora.Red(r.Error))+"\t"+r.URL)
+		ss = append(ss, fmt.Sprintf("%v", f.aurora.Red(r.Error))+"\t"+r.URL+formatLinkSource(r.Source))
 	}
 
 	sort.Strings(ss)
@@ -56,6 +92,86 @@ func (f *pageResultFormatter) formatErrorLinkResults(rs []*errorLinkResult) []st
 	return ss
 }
 
+func (f *pageResultFormatter) FormatLinkGroup(g *linkGroup) string {
+	ss := []string(nil)
+
+	for _, e := range g.Errors {
+		ss = append(ss, f.formatLinkGroupMessage(fmt.Sprint(f.aurora.Red(e.Message)), e)...)
+	}
+
+	for _, w := range g.Warnings {
+		ss = append(ss, f.formatLinkGroupMessage(fmt.Sprint(f.aurora.Yellow(w.Message)), w)...)
+	}
+
+	return strings.Join(
+		append([]string{fmt.Sprint(f.aurora.Yellow(g.URL))}, formatMessages(ss)...),
+		"\n",
+	)
+}
+
+func (*pageResultFormatter) formatLinkGroupMessage(s string, m *linkGroupMessage) []string {
+	ss := []string{s}
+
+	for _, r := range m.Referrers {
+		ss = append(ss, "\t"+r)
+	}
+
+	if n := m.ReferrerCount - len(m.Referrers); n > 0 {
+		ss = append(ss, fmt.Sprintf("\t... and %v more", n))
+	}
+
+	return ss
+}
+
+func (f *pageResultFormatter) FormatSummary(s *summary) string {
+	ss := []string{
+		fmt.Sprintf("pages: %v", s.Pages),
+		fmt.Sprintf("links: %v", s.Links),
+		fmt.Sprintf("successes: %v", f.aurora.Green(s.Successes)),
+		fmt.Sprintf("warnings: %v", f.aurora.Yellow(s.Warnings)),
+		fmt.Sprintf("failures: %v", f.aurora.Red(s.Failures)),
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(s.Categories)) {
+		ss = append(ss, fmt.Sprintf("\t%v: %v", k, s.Categories[k]))
+	}
+
+	if len(s.StatusCodes) != 0 {
+		ss = append(ss, "status codes:")
+
+		for _, c := range slices.Sorted(maps.Keys(s.StatusCodes)) {
+			ss = append(ss, fmt.Sprintf("\t%v: %v", c, s.StatusCodes[c]))
+		}
+	}
+
+	if len(s.FailingHosts) != 0 {
+		ss = append(ss, "top failing hosts:")
+
+		for _, h := range s.FailingHosts {
+			ss = append(ss, fmt.Sprintf("\t%v: %v", h.Name, h.Failures))
+		}
+	}
+
+	ss = append(
+		ss,
+		fmt.Sprintf("bytes downloaded: %v", s.Bytes),
+		fmt.Sprintf("duration: %v", s.Duration.Round(time.Millisecond)),
+	)
+
+	return strings.Join(
+		append([]string{fmt.Sprint(f.aurora.Yellow("Summary"))}, formatMessages(ss)...),
+		"\n",
+	)
+}
+
+func formatLinkSource(s *linkSource) string {
+	if s == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%v)", s)
+}
+
 func formatMessages(ss []string) []string {
 	ts := make([]string, 0, len(ss))
 
@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/bradleyjkemp/cupaloy"
 )
@@ -10,7 +11,7 @@ import (
 func TestPageResultFormatterFormatEmptyResult(t *testing.T) {
 	cupaloy.SnapshotT(t,
 		newPageResultFormatter(false, true).Format(
-			&pageResult{"http://foo.com", nil, nil},
+			&pageResult{URL: "http://foo.com"},
 		),
 	)
 }
@@ -19,11 +20,10 @@ func TestPageResultFormatterFormatSuccessLinkResults(t *testing.T) {
 	cupaloy.SnapshotT(t,
 		newPageResultFormatter(false, true).Format(
 			&pageResult{
-				"http://foo.com",
-				[]*successLinkResult{
-					{"http://foo.com", 200},
+				URL: "http://foo.com",
+				SuccessLinkResults: []*successLinkResult{
+					{URL: "http://foo.com", StatusCode: 200},
 				},
-				nil,
 			},
 		),
 	)
@@ -33,12 +33,12 @@ func TestPageResultFormatterFormatErrorLinkResults(t *testing.T) {
 	cupaloy.SnapshotT(t,
 		newPageResultFormatter(false, true).Format(
 			&pageResult{
-				"http://foo.com",
-				[]*successLinkResult{
-					{"http://foo.com", 200},
+				URL: "http://foo.com",
+				SuccessLinkResults: []*successLinkResult{
+					{URL: "http://foo.com", StatusCode: 200},
 				},
-				[]*errorLinkResult{
-					{"http://foo.com", errors.New("500")},
+				ErrorLinkResults: []*errorLinkResult{
+					{URL: "http://foo.com", Error: errors.New("500")},
 				},
 			},
 		),
@@ -49,11 +49,10 @@ func TestPageResultFormatterFormatSuccessLinkResultsVerbosely(t *testing.T) {
 	cupaloy.SnapshotT(t,
 		newPageResultFormatter(true, true).Format(
 			&pageResult{
-				"http://foo.com",
-				[]*successLinkResult{
-					{"http://foo.com", 200},
+				URL: "http://foo.com",
+				SuccessLinkResults: []*successLinkResult{
+					{URL: "http://foo.com", StatusCode: 200},
 				},
-				nil,
 			},
 		),
 	)
@@ -63,12 +62,12 @@ func TestPageResultFormatterFormatErrorLinkResultsVerbosely(t *testing.T) {
 	cupaloy.SnapshotT(t,
 		newPageResultFormatter(true, true).Format(
 			&pageResult{
-				"http://foo.com",
-				[]*successLinkResult{
-					{"http://foo.com", 200},
+				URL: "http://foo.com",
+				SuccessLinkResults: []*successLinkResult{
+					{URL: "http://foo.com", StatusCode: 200},
 				},
-				[]*errorLinkResult{
-					{"http://foo.com", errors.New("500")},
+				ErrorLinkResults: []*errorLinkResult{
+					{URL: "http://foo.com", Error: errors.New("500")},
 				},
 			},
 		),
@@ -79,12 +78,11 @@ func TestPageResultFormatterSortSuccessLinkResults(t *testing.T) {
 	cupaloy.SnapshotT(t,
 		newPageResultFormatter(true, true).Format(
 			&pageResult{
-				"http://foo.com",
-				[]*successLinkResult{
-					{"http://foo.com", 200},
-					{"http://bar.com", 200},
+				URL: "http://foo.com",
+				SuccessLinkResults: []*successLinkResult{
+					{URL: "http://foo.com", StatusCode: 200},
+					{URL: "http://bar.com", StatusCode: 200},
 				},
-				nil,
 			},
 		),
 	)
@@ -94,13 +92,101 @@ func TestPageResultFormatterSortErrorLinkResults(t *testing.T) {
 	cupaloy.SnapshotT(t,
 		newPageResultFormatter(false, true).Format(
 			&pageResult{
-				"http://foo.com",
-				nil,
-				[]*errorLinkResult{
-					{"http://foo.com", errors.New("500")},
-					{"http://bar.com", errors.New("500")},
+				URL: "http://foo.com",
+				ErrorLinkResults: []*errorLinkResult{
+					{URL: "http://foo.com", Error: errors.New("500")},
+					{URL: "http://bar.com", Error: errors.New("500")},
 				},
 			},
 		),
 	)
 }
+
+func TestPageResultFormatterFormatSuccessLinkResultsWithDurations(t *testing.T) {
+	cupaloy.SnapshotT(t,
+		newPageResultFormatter(true, true).Format(
+			&pageResult{
+				URL: "http://foo.com",
+				SuccessLinkResults: []*successLinkResult{
+					{
+						URL:             "http://foo.com",
+						StatusCode:      200,
+						TimeToFirstByte: 21 * time.Millisecond,
+						Duration:        42 * time.Millisecond,
+					},
+				},
+			},
+		),
+	)
+}
+
+func TestPageResultFormatterFormatWarnings(t *testing.T) {
+	cupaloy.SnapshotT(t,
+		newPageResultFormatter(false, true).Format(
+			&pageResult{
+				URL: "http://foo.com",
+				SuccessLinkResults: []*successLinkResult{
+					{URL: "http://foo.com", StatusCode: 200},
+				},
+				WarningLinkResults: []*warningLinkResult{
+					{successLinkResult{URL: "http://bar.com", StatusCode: 200}, []string{"slow response (2s)"}},
+				},
+			},
+		),
+	)
+}
+
+func TestPageResultFormatterFormatSummary(t *testing.T) {
+	cupaloy.SnapshotT(t, newPageResultFormatter(false, false).FormatSummary(newTestSummary()))
+}
+
+func TestPageResultFormatterFormatErrorLinkResultsWithSources(t *testing.T) {
+	cupaloy.SnapshotT(t,
+		newPageResultFormatter(false, false).Format(
+			&pageResult{
+				URL: "http://foo.com",
+				ErrorLinkResults: []*errorLinkResult{
+					{URL: "http://foo.com/foo", Error: errors.New("404"), Source: &linkSource{Line: 42, Column: 3, Element: "a", Attribute: "href", Text: "bar"}},
+				},
+			},
+		),
+	)
+}
+
+func TestPageResultFormatterFormatLinkGroup(t *testing.T) {
+	cupaloy.SnapshotT(t,
+		newPageResultFormatter(false, false).FormatLinkGroup(
+			&linkGroup{
+				"http://foo.com/foo",
+				[]*linkGroupMessage{
+					{"404", []string{"http://foo.com", "http://foo.com/bar"}, 5},
+					{"timeout", []string{"http://foo.com/baz"}, 1},
+				},
+				[]*linkGroupMessage{
+					{"slow response (2s)", []string{"http://foo.com"}, 1},
+				},
+			},
+		),
+	)
+}
+
+func TestPageResultFormatterFormatRedirectedSuccessLinkResultsVerbosely(t *testing.T) {
+	cupaloy.SnapshotT(t,
+		newPageResultFormatter(true, false).Format(
+			&pageResult{
+				URL: "http://foo.com",
+				SuccessLinkResults: []*successLinkResult{
+					{URL: "http://foo.com/foo", StatusCode: 200, RedirectURL: "http://foo.com/bar"},
+				},
+			},
+		),
+	)
+}
+
+func TestPageResultFormatterFormatPageWarnings(t *testing.T) {
+	cupaloy.SnapshotT(t,
+		newPageResultFormatter(false, false).Format(
+			&pageResult{URL: "http://foo.com", Warnings: []string{"duplicate id #foo"}},
+		),
+	)
+}
@@ -7,6 +7,15 @@ import (
 )
 
 func TestPageResultOK(t *testing.T) {
-	assert.True(t, (&pageResult{"", nil, nil}).OK())
-	assert.False(t, (&pageResult{"", nil, []*errorLinkResult{{}}}).OK())
+	assert.True(t, (&pageResult{}).OK())
+	assert.False(t, (&pageResult{ErrorLinkResults: []*errorLinkResult{{}}}).OK())
+}
+
+func TestPageResultWarned(t *testing.T) {
+	assert.False(t, (&pageResult{}).Warned())
+	assert.True(t, (&pageResult{WarningLinkResults: []*warningLinkResult{{}}}).Warned())
+}
+
+func TestPageResultWarnedWithPageWarnings(t *testing.T) {
+	assert.True(t, (&pageResult{Warnings: []string{"foo"}}).Warned())
 }
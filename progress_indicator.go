@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+const clearLine = "\r\033[K"
+
+type progressStatus struct {
+	CheckedPages     int
+	PendingPages     int
+	Requests         int
+	InFlightRequests int
+	Errors           int
+}
+
+// progressIndicator shows a live progress line on a terminal.
+type progressIndicator struct {
+	writer    io.Writer
+	status    func() progressStatus
+	interval  time.Duration
+	startTime time.Time
+	mutex     *sync.Mutex
+	done      chan struct{}
+	waitGroup *sync.WaitGroup
+	shown     bool
+}
+
+func newProgressIndicator(w io.Writer, f func() progressStatus, interval time.Duration) *progressIndicator {
+	return &progressIndicator{
+		writer:    w,
+		status:    f,
+		interval:  interval,
+		mutex:     &sync.Mutex{},
+		done:      make(chan struct{}),
+		waitGroup: &sync.WaitGroup{},
+	}
+}
+
+func (i *progressIndicator) Start() {
+	i.startTime = time.Now()
+	i.waitGroup.Add(1)
+
+	go func() {
+		defer i.waitGroup.Done()
+
+		t := time.NewTicker(i.interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-t.C:
+				i.show()
+			case <-i.done:
+				return
+			}
+		}
+	}()
+}
+
+func (i *progressIndicator) Stop() {
+	close(i.done)
+	i.waitGroup.Wait()
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.clear()
+}
+
+// Writer wraps a writer sharing a terminal so that its outputs do not overlap with progress lines.
+func (i *progressIndicator) Writer(w io.Writer) io.Writer {
+	return progressIndicatorWriter{i, w}
+}
+
+func (i *progressIndicator) show() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.write(clearLine + formatProgressStatus(i.status(), time.Since(i.startTime)))
+	i.shown = true
+}
+
+func (i *progressIndicator) clear() {
+	if i.shown {
+		i.write(clearLine)
+		i.shown = false
+	}
+}
+
+func (i *progressIndicator) write(s string) {
+	if _, err := fmt.Fprint(i.writer, s); err != nil {
+		panic(err)
+	}
+}
+
+func formatProgressStatus(s progressStatus, d time.Duration) string {
+	r := 0.0
+
+	if d > 0 {
+		r = float64(s.Requests) / d.Seconds()
+	}
+
+	return fmt.Sprintf(
+		"pages: %v (pending: %v) | requests: %v (in flight: %v, %.1f/s) | errors: %v",
+		s.CheckedPages,
+		s.PendingPages,
+		s.Requests,
+		s.InFlightRequests,
+		r,
+		s.Errors,
+	)
+}
+
+type progressIndicatorWriter struct {
+	indicator *progressIndicator
+	writer    io.Writer
+}
+
+func (w progressIndicatorWriter) Write(bs []byte) (int, error) {
+	w.indicator.mutex.Lock()
+	defer w.indicator.mutex.Unlock()
+
+	w.indicator.clear()
+
+	return w.writer.Write(bs)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressIndicatorShowProgress(t *testing.T) {
+	b := &bytes.Buffer{}
+	i := newProgressIndicator(
+		b,
+		func() progressStatus { return progressStatus{CheckedPages: 1} },
+		time.Millisecond,
+	)
+
+	i.Start()
+	time.Sleep(10 * time.Millisecond)
+	i.Stop()
+
+	assert.Contains(t, b.String(), "pages: 1")
+	assert.True(t, bytes.HasSuffix(b.Bytes(), []byte(clearLine)))
+}
+
+func TestProgressIndicatorClearProgressBeforeWrite(t *testing.T) {
+	b := &bytes.Buffer{}
+	i := newProgressIndicator(b, func() progressStatus { return progressStatus{} }, time.Hour)
+
+	i.show()
+	b.Reset()
+
+	_, err := i.Writer(b).Write([]byte("foo"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, clearLine+"foo", b.String())
+}
+
+func TestProgressIndicatorNotClearProgressNotShown(t *testing.T) {
+	b := &bytes.Buffer{}
+	i := newProgressIndicator(b, func() progressStatus { return progressStatus{} }, time.Hour)
+
+	i.Start()
+	i.Stop()
+
+	assert.Equal(t, "", b.String())
+}
+
+func TestFormatProgressStatus(t *testing.T) {
+	assert.Equal(
+		t,
+		"pages: 1 (pending: 2) | requests: 10 (in flight: 3, 5.0/s) | errors: 4",
+		formatProgressStatus(
+			progressStatus{
+				CheckedPages:     1,
+				PendingPages:     2,
+				Requests:         10,
+				InFlightRequests: 3,
+				Errors:           4,
+			},
+			2*time.Second,
+		),
+	)
+}
+
+func TestFormatProgressStatusWithoutElapsedTime(t *testing.T) {
+	assert.Contains(t, formatProgressStatus(progressStatus{Requests: 1}, 0), "0.0/s")
+}
@@ -0,0 +1,13 @@
+package main
+
+// redirectHop is a redirection followed on a request.
+type redirectHop struct {
+	URL        string
+	StatusCode int
+	Location   string
+}
+
+// isPermanentRedirect returns true if a status code is a permanent redirection.
+func isPermanentRedirect(c int) bool {
+	return c == 301 || c == 308
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"time"
 )
 
 type redirectHttpClient struct {
@@ -27,6 +28,9 @@ func (c *redirectHttpClient) Get(u *url.URL, header http.Header) (httpResponse,
 		return nil, err
 	}
 
+	hs := []*redirectHop(nil)
+	d := time.Duration(0)
+
 	for i := range c.maxRedirections + 1 {
 		for _, c := range cj.Cookies(u) {
 			header.Add("cookie", c.String())
@@ -38,7 +42,11 @@ func (c *redirectHttpClient) Get(u *url.URL, header http.Header) (httpResponse,
 		} else if err != nil {
 			return nil, fmt.Errorf("%w (following redirect %v)", err, u.String())
 		} else if c := r.StatusCode(); c < 300 || c >= 400 {
-			return r, nil
+			if len(hs) == 0 {
+				return r, nil
+			}
+
+			return newRedirectedHttpResponse(r, hs, d), nil
 		}
 
 		s := r.Header("Location")
@@ -47,11 +55,15 @@ func (c *redirectHttpClient) Get(u *url.URL, header http.Header) (httpResponse,
 			return nil, errors.New("location header not set")
 		}
 
-		u, err = u.Parse(s)
+		v, err := u.Parse(s)
 		if err != nil {
 			return nil, err
 		}
 
+		hs = append(hs, &redirectHop{u.String(), r.StatusCode(), v.String()})
+		d += r.Duration()
+		u = v
+
 		cj.SetCookies(u, parseCookies(r.Header("set-cookie")))
 	}
 
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -126,6 +127,35 @@ func TestRedirectHttpClientGetWithRedirects(t *testing.T) {
 	assert.Equal(t, maxRedirections, i)
 }
 
+func TestRedirectHttpClientGetWithRedirectTimes(t *testing.T) {
+	u, err := url.Parse(testUrl)
+	assert.Nil(t, err)
+
+	i := 0
+	r, err := newRedirectHttpClient(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				i++
+				r := newFakeHtmlResponse(testUrl, "")
+
+				if i < 3 {
+					r = newFakeHttpResponse(301, testUrl, nil, map[string]string{"location": testUrl})
+				}
+
+				r.timeToFirstByte = time.Duration(i) * time.Millisecond
+				r.duration = time.Duration(i) * 10 * time.Millisecond
+
+				return r, nil
+			},
+		),
+		42,
+	).Get(u, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 33*time.Millisecond, r.TimeToFirstByte())
+	assert.Equal(t, 60*time.Millisecond, r.Duration())
+}
+
 func TestRedirectHttpClientGetWithRelativeRedirect(t *testing.T) {
 	const maxRedirections = 42
 
@@ -265,3 +295,54 @@ func TestRedirectHttpClientFailAfterRedirect(t *testing.T) {
 	assert.Nil(t, r)
 	assert.Contains(t, err.Error(), "following redirect http://foo.com/foo")
 }
+
+func TestRedirectHttpClientRecordRedirects(t *testing.T) {
+	u, err := url.Parse(testUrl)
+
+	assert.Nil(t, err)
+
+	r, err := newRedirectHttpClient(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				switch u.String() {
+				case testUrl:
+					return newFakeHttpResponse(301, testUrl, nil, map[string]string{"location": "/foo"}), nil
+				case "http://foo.com/foo":
+					return newFakeHttpResponse(302, u.String(), nil, map[string]string{"location": "http://bar.com"}), nil
+				}
+
+				return newFakeHtmlResponse(u.String(), ""), nil
+			},
+		),
+		42,
+	).Get(u, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, r.StatusCode())
+	assert.Equal(
+		t,
+		[]*redirectHop{
+			{testUrl, 301, "http://foo.com/foo"},
+			{"http://foo.com/foo", 302, "http://bar.com"},
+		},
+		r.Redirects(),
+	)
+}
+
+func TestRedirectHttpClientRecordNoRedirects(t *testing.T) {
+	u, err := url.Parse(testUrl)
+
+	assert.Nil(t, err)
+
+	r, err := newRedirectHttpClient(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeHtmlResponse(testUrl, ""), nil
+			},
+		),
+		42,
+	).Get(u, nil)
+
+	assert.Nil(t, err)
+	assert.Nil(t, r.Redirects())
+}
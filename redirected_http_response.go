@@ -0,0 +1,27 @@
+package main
+
+import "time"
+
+// redirectedHttpResponse is a final response of redirections timed from their first request.
+type redirectedHttpResponse struct {
+	httpResponse
+	redirects []*redirectHop
+	// redirectDuration is a total duration of responses before a final one.
+	redirectDuration time.Duration
+}
+
+func newRedirectedHttpResponse(r httpResponse, hs []*redirectHop, d time.Duration) httpResponse {
+	return &redirectedHttpResponse{r, hs, d}
+}
+
+func (r *redirectedHttpResponse) Redirects() []*redirectHop {
+	return r.redirects
+}
+
+func (r *redirectedHttpResponse) TimeToFirstByte() time.Duration {
+	return r.redirectDuration + r.httpResponse.TimeToFirstByte()
+}
+
+func (r *redirectedHttpResponse) Duration() time.Duration {
+	return r.redirectDuration + r.httpResponse.Duration()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var invisibleAtoms = map[atom.Atom]struct{}{
+	atom.Head:     {},
+	atom.Noscript: {},
+	atom.Script:   {},
+	atom.Style:    {},
+	atom.Template: {},
+}
+
+var inlineAtoms = map[atom.Atom]struct{}{
+	atom.A:      {},
+	atom.Abbr:   {},
+	atom.B:      {},
+	atom.Bdi:    {},
+	atom.Bdo:    {},
+	atom.Cite:   {},
+	atom.Code:   {},
+	atom.Data:   {},
+	atom.Dfn:    {},
+	atom.Em:     {},
+	atom.I:      {},
+	atom.Kbd:    {},
+	atom.Label:  {},
+	atom.Mark:   {},
+	atom.Q:      {},
+	atom.S:      {},
+	atom.Samp:   {},
+	atom.Small:  {},
+	atom.Span:   {},
+	atom.Strong: {},
+	atom.Sub:    {},
+	atom.Sup:    {},
+	atom.Time:   {},
+	atom.U:      {},
+	atom.Var:    {},
+}
+
+// renderedText extracts visible text of a document with whitespace collapsed.
+// Block elements are separated by spaces.
+func renderedText(n *html.Node) string {
+	b := &strings.Builder{}
+	renderText(b, n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func renderText(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(n.Data)
+		return
+	case html.ElementNode:
+		if _, ok := invisibleAtoms[n.DataAtom]; ok {
+			return
+		} else if _, ok := inlineAtoms[n.DataAtom]; !ok {
+			b.WriteByte(' ')
+			defer b.WriteByte(' ')
+		}
+	}
+
+	for n := n.FirstChild; n != nil; n = n.NextSibling {
+		renderText(b, n)
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/html"
+)
+
+func TestRenderedText(t *testing.T) {
+	for _, c := range []struct {
+		html string
+		text string
+	}{
+		{``, ""},
+		{`foo`, "foo"},
+		{"  foo \n bar  ", "foo bar"},
+		{`<p>foo</p><p>bar</p>`, "foo bar"},
+		{`foo<b>bar</b>`, "foobar"},
+		{`foo<br>bar`, "foo bar"},
+		{`<script>foo</script>bar`, "bar"},
+		{`<style>foo</style>bar`, "bar"},
+		{`<template>foo</template>bar`, "bar"},
+	} {
+		n, err := html.Parse(strings.NewReader(htmlWithBody(c.html)))
+		assert.Nil(t, err)
+
+		assert.Equal(t, c.text, renderedText(n))
+	}
+}
+
+func TestRenderedTextIgnoreHead(t *testing.T) {
+	n, err := html.Parse(strings.NewReader(htmlWithHead(`<title>foo</title>`)))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "hi", renderedText(n))
+}
@@ -21,6 +21,22 @@ func (p *sitemapPage) Fragments() map[string]struct{} {
 	return nil
 }
 
+func (p *sitemapPage) Text() string {
+	return ""
+}
+
 func (p *sitemapPage) Links() map[string]error {
 	return p.links
 }
+
+func (p *sitemapPage) LinkSources() map[string]*linkSource {
+	return nil
+}
+
+func (p *sitemapPage) Warnings() []string {
+	return nil
+}
+
+func (p *sitemapPage) MetaRefreshURL() *url.URL {
+	return nil
+}
@@ -0,0 +1,19 @@
+package main
+
+import "strconv"
+
+type statusCodeError struct {
+	statusCode int
+}
+
+func newStatusCodeError(c int) error {
+	return &statusCodeError{c}
+}
+
+func (e *statusCodeError) Error() string {
+	return strconv.Itoa(e.statusCode)
+}
+
+func (e *statusCodeError) StatusCode() int {
+	return e.statusCode
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"sort"
+	"time"
+)
+
+const maxSummaryHosts = 10
+
+type summary struct {
+	Pages        int
+	Links        int
+	Successes    int
+	Warnings     int
+	Failures     int
+	Categories   map[string]int
+	StatusCodes  map[int]int
+	FailingHosts []*summaryHost
+	Bytes        int
+	Duration     time.Duration
+}
+
+type summaryHost struct {
+	Name     string
+	Failures int
+}
+
+// summaryCollector aggregates statistics of page results.
+// Links are counted only once even if they are found in multiple pages.
+type summaryCollector struct {
+	startTime time.Time
+	pages     int
+	links     map[string]struct{}
+	successes int
+	warnings  int
+	failures  []*errorLinkResult
+	bytes     int
+}
+
+func newSummaryCollector(t time.Time) *summaryCollector {
+	return &summaryCollector{startTime: t, links: map[string]struct{}{}}
+}
+
+func (c *summaryCollector) Add(r *pageResult) {
+	c.pages++
+
+	for _, r := range r.SuccessLinkResults {
+		if c.addLink(r.URL) {
+			c.successes++
+			c.bytes += r.BodySize
+		}
+	}
+
+	for _, r := range r.WarningLinkResults {
+		if c.addLink(r.URL) {
+			c.warnings++
+			c.bytes += r.BodySize
+		}
+	}
+
+	for _, r := range r.ErrorLinkResults {
+		if c.addLink(r.URL) {
+			c.failures = append(c.failures, r)
+		}
+	}
+}
+
+func (c *summaryCollector) Summary() *summary {
+	s := &summary{
+		Pages:       c.pages,
+		Links:       len(c.links),
+		Successes:   c.successes,
+		Warnings:    c.warnings,
+		Failures:    len(c.failures),
+		Categories:  map[string]int{},
+		StatusCodes: map[int]int{},
+		Bytes:       c.bytes,
+		Duration:    time.Since(c.startTime),
+	}
+
+	hs := map[string]int{}
+
+	for _, r := range c.failures {
+		s.Categories[errorCategory(r.Error)]++
+
+		if c := r.StatusCode(); c != 0 {
+			s.StatusCodes[c]++
+		}
+
+		if u, err := url.Parse(r.URL); err == nil && u.Hostname() != "" {
+			hs[u.Hostname()]++
+		}
+	}
+
+	for h, c := range hs {
+		s.FailingHosts = append(s.FailingHosts, &summaryHost{h, c})
+	}
+
+	sort.Slice(s.FailingHosts, func(i, j int) bool {
+		h, g := s.FailingHosts[i], s.FailingHosts[j]
+		return h.Failures > g.Failures || h.Failures == g.Failures && h.Name < g.Name
+	})
+
+	if len(s.FailingHosts) > maxSummaryHosts {
+		s.FailingHosts = s.FailingHosts[:maxSummaryHosts]
+	}
+
+	return s
+}
+
+func (c *summaryCollector) addLink(s string) bool {
+	if _, ok := c.links[s]; ok {
+		return false
+	}
+
+	c.links[s] = struct{}{}
+
+	return true
+}
+
+func errorCategory(err error) string {
+	var s *statusCodeError
+	var d *net.DNSError
+	var t interface{ Timeout() bool }
+	var u *url.Error
+	var c x509.CertificateInvalidError
+	var h x509.HostnameError
+	var a x509.UnknownAuthorityError
+	var o *net.OpError
+
+	switch {
+	case errors.Is(err, errMixedContent):
+		return "mixed_content"
+	case errors.As(err, &s):
+		return "status_code"
+	case errors.As(err, &t) && t.Timeout():
+		return "timeout"
+	case errors.As(err, &d):
+		return "dns"
+	case errors.As(err, &c) || errors.As(err, &h) || errors.As(err, &a):
+		return "tls"
+	case errors.As(err, &o):
+		return "connection"
+	case errors.As(err, &u):
+		return "invalid_url"
+	}
+
+	return "other"
+}
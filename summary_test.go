@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func TestSummaryCollectorSummary(t *testing.T) {
+	c := newSummaryCollector(time.Now())
+
+	c.Add(&pageResult{
+		URL: "http://foo.com",
+		SuccessLinkResults: []*successLinkResult{
+			{URL: "http://foo.com/foo", StatusCode: 200, BodySize: 42},
+			{URL: "http://foo.com/bar", StatusCode: 200, BodySize: 8},
+		},
+		ErrorLinkResults: []*errorLinkResult{
+			{URL: "http://foo.com/baz", Error: newStatusCodeError(404)},
+			{URL: "http://bar.com/baz", Error: newStatusCodeError(404)},
+		},
+	})
+	c.Add(&pageResult{
+		URL: "http://foo.com/foo",
+		SuccessLinkResults: []*successLinkResult{
+			{URL: "http://foo.com/foo", StatusCode: 200, BodySize: 42},
+		},
+		WarningLinkResults: []*warningLinkResult{
+			{successLinkResult{URL: "http://foo.com/quux", StatusCode: 200, BodySize: 1}, []string{"foo"}},
+		},
+		ErrorLinkResults: []*errorLinkResult{
+			{URL: "http://foo.com/baz", Error: newStatusCodeError(404)},
+			{URL: "http://foo.com/qux", Error: fasthttp.ErrTimeout},
+		},
+	})
+
+	s := c.Summary()
+
+	assert.Equal(t, 2, s.Pages)
+	assert.Equal(t, 6, s.Links)
+	assert.Equal(t, 2, s.Successes)
+	assert.Equal(t, 1, s.Warnings)
+	assert.Equal(t, 3, s.Failures)
+	assert.Equal(t, map[string]int{"status_code": 2, "timeout": 1}, s.Categories)
+	assert.Equal(t, map[int]int{404: 2}, s.StatusCodes)
+	assert.Equal(t, []*summaryHost{{"foo.com", 2}, {"bar.com", 1}}, s.FailingHosts)
+	assert.Equal(t, 51, s.Bytes)
+}
+
+func TestSummaryCollectorLimitFailingHosts(t *testing.T) {
+	c := newSummaryCollector(time.Now())
+	es := []*errorLinkResult{}
+
+	for i := range 2 * maxSummaryHosts {
+		es = append(es, &errorLinkResult{URL: fmt.Sprintf("http://foo%v.com", i), Error: errors.New("foo")})
+	}
+
+	c.Add(&pageResult{URL: "http://foo.com", ErrorLinkResults: es})
+
+	assert.Equal(t, maxSummaryHosts, len(c.Summary().FailingHosts))
+}
+
+func TestErrorCategory(t *testing.T) {
+	for _, c := range []struct {
+		error    error
+		category string
+	}{
+		{newStatusCodeError(404), "status_code"},
+		{fmt.Errorf("%w (following redirect http://foo.com)", newStatusCodeError(500)), "status_code"},
+		{fasthttp.ErrTimeout, "timeout"},
+		{&net.DNSError{Err: "no such host", Name: "foo.com"}, "dns"},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, "connection"},
+		{fmt.Errorf("%w; upgrade to https://foo.com", errMixedContent), "mixed_content"},
+		{errors.New("foo"), "other"},
+	} {
+		assert.Equal(t, c.category, errorCategory(c.error))
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const tapVersion = 14
+
+var tapDescriptionEscaper = strings.NewReplacer(`\`, `\\`, "#", `\#`)
+
+// formatTAPPageResult formats a page result as a subtest of the given test point number.
+func formatTAPPageResult(r *pageResult, n int) string {
+	ss := []string{
+		"# Subtest: " + r.URL,
+		fmt.Sprintf("    1..%v", len(r.SuccessLinkResults)+len(r.WarningLinkResults)+len(r.ErrorLinkResults)),
+	}
+
+	for _, w := range r.Warnings {
+		ss = append(ss, "    # warning: "+w)
+	}
+
+	i := 0
+
+	for _, l := range r.SuccessLinkResults {
+		i++
+		ss = append(ss, formatTAPSuccessLinkResult(l, nil, i)...)
+	}
+
+	for _, l := range r.WarningLinkResults {
+		i++
+		ss = append(ss, formatTAPSuccessLinkResult(&l.successLinkResult, l.Warnings, i)...)
+	}
+
+	for _, l := range r.ErrorLinkResults {
+		i++
+		ss = append(
+			ss,
+			"    "+formatTAPTestPoint(false, i, l.URL),
+			"      ---",
+			"      error: "+formatYAMLString(l.Error.Error()),
+		)
+
+		if c := l.StatusCode(); c != 0 {
+			ss = append(ss, fmt.Sprintf("      status: %v", c))
+		}
+
+		if l.Source != nil {
+			ss = append(ss, "      source: "+formatYAMLString(l.Source.String()))
+		}
+
+		ss = append(ss, "      ...")
+	}
+
+	return strings.Join(append(ss, formatTAPTestPoint(r.OK(), n, r.URL)), "\n")
+}
+
+func formatTAPSuccessLinkResult(l *successLinkResult, warnings []string, n int) []string {
+	ss := []string{"    " + formatTAPTestPoint(true, n, l.URL)}
+	ys := []string(nil)
+
+	if l.RedirectURL != "" {
+		ys = append(ys, "      redirect_url: "+formatYAMLString(l.RedirectURL))
+	}
+
+	if len(warnings) != 0 {
+		ys = append(ys, "      warnings:")
+
+		for _, w := range warnings {
+			ys = append(ys, "        - "+formatYAMLString(w))
+		}
+	}
+
+	if len(ys) == 0 {
+		return ss
+	}
+
+	return append(append(append(ss, "      ---"), ys...), "      ...")
+}
+
+func formatTAPTestPoint(ok bool, n int, description string) string {
+	s := "ok"
+
+	if !ok {
+		s = "not ok"
+	}
+
+	return fmt.Sprintf("%v %v - %v", s, n, tapDescriptionEscaper.Replace(description))
+}
+
+// formatYAMLString formats a string as a double-quoted YAML scalar.
+// JSON strings are valid in YAML.
+func formatYAMLString(s string) string {
+	bs, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return string(bs)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSuccessTAPPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, formatTAPPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
+		}, 1))
+}
+
+func TestFormatErrorTAPPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, formatTAPPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200},
+			},
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("baz")},
+				{URL: "http://foo.com/baz", Error: newStatusCodeError(404)},
+			},
+		}, 2))
+}
+
+func TestFormatRedirectedTAPPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, formatTAPPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200, RedirectURL: "http://foo.com/bar"},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
+		}, 1))
+}
+
+func TestFormatTAPTestPointEscapingHash(t *testing.T) {
+	assert.Equal(
+		t,
+		`not ok 1 - http://foo.com/\#foo`,
+		formatTAPTestPoint(false, 1, "http://foo.com/#foo"),
+	)
+}
+
+func TestFormatWarningTAPPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, formatTAPPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			WarningLinkResults: []*warningLinkResult{
+				{successLinkResult{URL: "http://foo.com/foo", StatusCode: 200}, []string{"foo", "bar"}},
+			},
+		}, 1))
+}
+
+func TestFormatPageWarningTAPPageResult(t *testing.T) {
+	cupaloy.SnapshotT(t, formatTAPPageResult(
+		&pageResult{URL: "http://foo.com", Warnings: []string{"duplicate id #foo"}},
+		1,
+	))
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const fragmentDirectiveDelimiter = ":~:"
+
+// textFragment is a text directive of a URL fragment.
+// https://wicg.github.io/scroll-to-text-fragment/
+type textFragment struct {
+	Prefix string
+	Start  string
+	End    string
+	Suffix string
+}
+
+// parseTextFragments parses text directives in a percent-encoded fragment directive.
+// Directives other than text ones are ignored.
+func parseTextFragments(s string) ([]*textFragment, error) {
+	fs := []*textFragment{}
+
+	for _, s := range strings.Split(s, "&") {
+		s, ok := strings.CutPrefix(s, "text=")
+		if !ok {
+			continue
+		}
+
+		f, err := parseTextFragment(s)
+		if err != nil {
+			return nil, err
+		}
+
+		fs = append(fs, f)
+	}
+
+	return fs, nil
+}
+
+func parseTextFragment(s string) (*textFragment, error) {
+	ss := strings.Split(s, ",")
+	f := &textFragment{}
+
+	if s := ss[0]; strings.HasSuffix(s, "-") {
+		f.Prefix, ss = strings.TrimSuffix(s, "-"), ss[1:]
+	}
+
+	if len(ss) != 0 {
+		if s := ss[len(ss)-1]; strings.HasPrefix(s, "-") {
+			f.Suffix, ss = strings.TrimPrefix(s, "-"), ss[:len(ss)-1]
+		}
+	}
+
+	switch len(ss) {
+	case 1:
+		f.Start = ss[0]
+	case 2:
+		f.Start, f.End = ss[0], ss[1]
+	default:
+		return nil, fmt.Errorf("invalid text fragment %q", s)
+	}
+
+	for _, s := range []*string{&f.Prefix, &f.Start, &f.End, &f.Suffix} {
+		t, err := url.PathUnescape(*s)
+		if err != nil {
+			return nil, err
+		}
+
+		*s = normalizeText(t)
+	}
+
+	if f.Start == "" {
+		return nil, errors.New("empty text fragment")
+	}
+
+	return f, nil
+}
+
+func (f *textFragment) String() string {
+	ss := []string{}
+
+	if f.Prefix != "" {
+		ss = append(ss, f.Prefix+"-")
+	}
+
+	ss = append(ss, f.Start)
+
+	if f.End != "" {
+		ss = append(ss, f.End)
+	}
+
+	if f.Suffix != "" {
+		ss = append(ss, "-"+f.Suffix)
+	}
+
+	return strings.Join(ss, ",")
+}
+
+// Match finds a text fragment in normalized text.
+func (f *textFragment) Match(t string) bool {
+	for i := indexWord(t, f.Start, 0); i >= 0; i = indexWord(t, f.Start, i+1) {
+		if f.Prefix != "" && !strings.HasSuffix(strings.TrimRight(t[:i], " "), f.Prefix) {
+			continue
+		}
+
+		j := i + len(f.Start)
+
+		if f.End == "" {
+			if f.matchSuffix(t[j:]) {
+				return true
+			}
+
+			continue
+		}
+
+		for k := indexWord(t, f.End, j); k >= 0; k = indexWord(t, f.End, k+1) {
+			if f.matchSuffix(t[k+len(f.End):]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (f *textFragment) matchSuffix(t string) bool {
+	return f.Suffix == "" || strings.HasPrefix(strings.TrimLeft(t, " "), f.Suffix)
+}
+
+// indexWord finds a string in text at word boundaries from an index.
+func indexWord(t, s string, i int) int {
+	for i <= len(t) {
+		j := strings.Index(t[i:], s)
+
+		if j < 0 {
+			return -1
+		}
+
+		i += j
+
+		if isWordBoundary(t, i) && isWordBoundary(t, i+len(s)) {
+			return i
+		}
+
+		i++
+	}
+
+	return -1
+}
+
+func isWordBoundary(t string, i int) bool {
+	if i == 0 || i == len(t) {
+		return true
+	}
+
+	r, _ := utf8.DecodeLastRuneInString(t[:i])
+	s, _ := utf8.DecodeRuneInString(t[i:])
+
+	return !isWordRune(r) || !isWordRune(s)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// normalizeText collapses whitespaces and folds cases of text for matching.
+func normalizeText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
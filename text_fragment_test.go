@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTextFragments(t *testing.T) {
+	for _, c := range []struct {
+		directive string
+		fragments []*textFragment
+	}{
+		{"", []*textFragment{}},
+		{"foo=bar", []*textFragment{}},
+		{"text=foo", []*textFragment{{Start: "foo"}}},
+		{"text=Foo%20Bar", []*textFragment{{Start: "foo bar"}}},
+		{"text=foo,bar", []*textFragment{{Start: "foo", End: "bar"}}},
+		{"text=foo-,bar", []*textFragment{{Prefix: "foo", Start: "bar"}}},
+		{"text=foo,-bar", []*textFragment{{Start: "foo", Suffix: "bar"}}},
+		{"text=foo-,bar,baz,-qux", []*textFragment{{Prefix: "foo", Start: "bar", End: "baz", Suffix: "qux"}}},
+		{"text=foo%2C%20bar", []*textFragment{{Start: "foo, bar"}}},
+		{"text=foo&text=bar", []*textFragment{{Start: "foo"}, {Start: "bar"}}},
+	} {
+		fs, err := parseTextFragments(c.directive)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.fragments, fs)
+	}
+}
+
+func TestParseTextFragmentsError(t *testing.T) {
+	for _, s := range []string{"text=", "text=foo-,-bar", "text=foo,bar,baz", "text=%"} {
+		_, err := parseTextFragments(s)
+
+		assert.NotNil(t, err, s)
+	}
+}
+
+func TestTextFragmentString(t *testing.T) {
+	assert.Equal(
+		t,
+		"foo-,bar,baz,-qux",
+		(&textFragment{Prefix: "foo", Start: "bar", End: "baz", Suffix: "qux"}).String(),
+	)
+}
+
+func TestTextFragmentMatch(t *testing.T) {
+	for _, c := range []struct {
+		fragment textFragment
+		text     string
+		match    bool
+	}{
+		{textFragment{Start: "foo"}, "foo", true},
+		{textFragment{Start: "foo"}, "bar foo baz", true},
+		{textFragment{Start: "foo"}, "bar", false},
+		{textFragment{Start: "foo"}, "foobar", false},
+		{textFragment{Start: "foo"}, "foobar foo", true},
+		{textFragment{Start: "foo bar"}, "foo bar", true},
+		{textFragment{Start: "foo", End: "baz"}, "foo bar baz", true},
+		{textFragment{Start: "foo", End: "baz"}, "baz foo bar", false},
+		{textFragment{Prefix: "foo", Start: "bar"}, "foo bar", true},
+		{textFragment{Prefix: "foo", Start: "bar"}, "baz bar foo bar", true},
+		{textFragment{Prefix: "foo", Start: "bar"}, "baz bar", false},
+		{textFragment{Start: "foo", Suffix: "bar"}, "foo bar", true},
+		{textFragment{Start: "foo", Suffix: "bar"}, "foo baz", false},
+		{textFragment{Start: "foo", End: "bar", Suffix: "baz"}, "foo bar qux bar baz", true},
+		{textFragment{Start: "ä"}, "öä ä", true},
+	} {
+		assert.Equal(t, c.match, c.fragment.Match(c.text), c)
+	}
+}
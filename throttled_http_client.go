@@ -3,19 +3,22 @@ package main
 import (
 	"net/http"
 	"net/url"
+	"sync/atomic"
 )
 
 type throttledHttpClient struct {
 	client            httpClient
 	connections       semaphore
 	hostThrottlerPool *hostThrottlerPool
+	requests          atomic.Int64
+	inFlightRequests  atomic.Int64
 }
 
-func newThrottledHttpClient(c httpClient, requestPerSecond int, maxConnections, maxConnectionsPerHost int) httpClient {
+func newThrottledHttpClient(c httpClient, requestPerSecond int, maxConnections, maxConnectionsPerHost int) *throttledHttpClient {
 	return &throttledHttpClient{
-		c,
-		newSemaphore(maxConnections),
-		newHostThrottlerPool(requestPerSecond, maxConnectionsPerHost),
+		client:            c,
+		connections:       newSemaphore(maxConnections),
+		hostThrottlerPool: newHostThrottlerPool(requestPerSecond, maxConnectionsPerHost),
 	}
 }
 
@@ -27,5 +30,19 @@ func (c *throttledHttpClient) Get(u *url.URL, header http.Header) (httpResponse,
 	t.Request()
 	defer t.Release()
 
+	c.requests.Add(1)
+	c.inFlightRequests.Add(1)
+	defer c.inFlightRequests.Add(-1)
+
 	return c.client.Get(u, header)
 }
+
+// Requests returns a number of requests sent so far.
+func (c *throttledHttpClient) Requests() int {
+	return int(c.requests.Load())
+}
+
+// InFlightRequests returns a number of requests waiting for responses.
+func (c *throttledHttpClient) InFlightRequests() int {
+	return int(c.inFlightRequests.Load())
+}
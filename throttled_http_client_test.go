@@ -0,0 +1,30 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottledHttpClientCountRequests(t *testing.T) {
+	u, err := url.Parse(testUrl)
+	assert.Nil(t, err)
+
+	c := newThrottledHttpClient(
+		newFakeHttpClient(
+			func(u *url.URL) (*fakeHttpResponse, error) {
+				return newFakeHttpResponse(200, testUrl, nil, nil), nil
+			},
+		),
+		0,
+		1,
+		1,
+	)
+
+	_, err = c.Get(u, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, c.Requests())
+	assert.Equal(t, 0, c.InFlightRequests())
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// urlNormalizer normalizes URLs into keys so that equivalent ones are fetched and crawled only once.
+// Normalized URLs are never fetched nor reported themselves.
+// A nil normalizer leaves URLs as they are.
+type urlNormalizer struct {
+	trackingParameters []string
+}
+
+// newURLNormalizer creates a URL normalizer stripping query parameters matched with given glob patterns.
+func newURLNormalizer(ps []string) (*urlNormalizer, error) {
+	for _, p := range ps {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid tracking parameter pattern: %v", p)
+		}
+	}
+
+	return &urlNormalizer{ps}, nil
+}
+
+// Normalize lowercases schemes and hosts, drops default ports, sorts query parameters, and strips
+// tracking ones.
+func (n *urlNormalizer) Normalize(u *url.URL) *url.URL {
+	if n == nil || u.Opaque != "" {
+		return u
+	}
+
+	v := *u
+	v.Scheme = strings.ToLower(v.Scheme)
+	v.Host = strings.ToLower(v.Host)
+
+	if p, ok := defaultPorts[v.Scheme]; ok && v.Port() == p {
+		v.Host = strings.TrimSuffix(v.Host, ":"+p)
+	}
+
+	if v.Host != "" && v.Path == "" {
+		v.Path = "/"
+	}
+
+	v.RawQuery = n.normalizeQuery(v.RawQuery)
+	v.ForceQuery = false
+
+	return &v
+}
+
+// NormalizeString normalizes a URL string. It returns the string as it is if it is invalid.
+func (n *urlNormalizer) NormalizeString(s string) string {
+	if n == nil {
+		return s
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return s
+	}
+
+	return n.Normalize(u).String()
+}
+
+// DeduplicateLinks removes links equivalent to others keeping the least ones in their original forms.
+// Sources of removed links are merged into ones of kept links.
+func (n *urlNormalizer) DeduplicateLinks(ls map[string]error, ss map[string]*linkSource) {
+	if n == nil {
+		return
+	}
+
+	ks := map[string]string{}
+
+	for _, u := range slices.Sorted(maps.Keys(ls)) {
+		if ls[u] != nil {
+			continue
+		}
+
+		k := n.NormalizeString(u)
+		v, ok := ks[k]
+
+		if !ok {
+			ks[k] = u
+			continue
+		}
+
+		if s, ok := ss[u]; ok {
+			addLinkSource(ss, v, s)
+		}
+
+		delete(ls, u)
+		delete(ss, u)
+	}
+}
+
+func (n *urlNormalizer) normalizeQuery(q string) string {
+	ps := []string{}
+
+	for _, p := range strings.Split(q, "&") {
+		if p != "" && !n.isTrackingParameter(queryParameterName(p)) {
+			ps = append(ps, p)
+		}
+	}
+
+	slices.SortStableFunc(ps, func(p, q string) int {
+		return strings.Compare(queryParameterName(p), queryParameterName(q))
+	})
+
+	return strings.Join(ps, "&")
+}
+
+func (n *urlNormalizer) isTrackingParameter(s string) bool {
+	for _, p := range n.trackingParameters {
+		if ok, _ := path.Match(p, s); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func queryParameterName(p string) string {
+	p, _, _ = strings.Cut(p, "=")
+
+	if s, err := url.QueryUnescape(p); err == nil {
+		return s
+	}
+
+	return p
+}
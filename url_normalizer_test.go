@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLNormalizerNormalize(t *testing.T) {
+	n, err := newURLNormalizer([]string{"utm_*", "fbclid"})
+	assert.Nil(t, err)
+
+	for _, c := range []struct {
+		source string
+		target string
+	}{
+		{"http://foo.com/bar", "http://foo.com/bar"},
+		{"HTTP://Foo.COM/Bar", "http://foo.com/Bar"},
+		{"http://foo.com:80/bar", "http://foo.com/bar"},
+		{"https://foo.com:443/bar", "https://foo.com/bar"},
+		{"http://foo.com:443/bar", "http://foo.com:443/bar"},
+		{"http://foo.com:8080/bar", "http://foo.com:8080/bar"},
+		{"http://[::1]:80/bar", "http://[::1]/bar"},
+		{"http://foo.com", "http://foo.com/"},
+		{"http://foo.com/bar?", "http://foo.com/bar"},
+		{"http://foo.com/bar?b=2&a=1", "http://foo.com/bar?a=1&b=2"},
+		{"http://foo.com/bar?b=1&a=2&b=0", "http://foo.com/bar?a=2&b=1&b=0"},
+		{"http://foo.com/bar?b%20c=1&b=2", "http://foo.com/bar?b=2&b%20c=1"},
+		{"http://foo.com/bar?a&&b=1", "http://foo.com/bar?a&b=1"},
+		{"http://foo.com/bar?utm_source=baz&a=1&fbclid=qux", "http://foo.com/bar?a=1"},
+		{"http://foo.com/bar?utm_source=baz", "http://foo.com/bar"},
+		{"http://foo.com/bar?futm_source=baz", "http://foo.com/bar?futm_source=baz"},
+		{"http://foo.com/bar?a=1#baz", "http://foo.com/bar?a=1#baz"},
+		{"mailto:foo@bar.com", "mailto:foo@bar.com"},
+	} {
+		assert.Equal(t, c.target, n.NormalizeString(c.source), c.source)
+	}
+}
+
+func TestURLNormalizerNormalizeWithoutTrackingParameters(t *testing.T) {
+	n, err := newURLNormalizer(nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "http://foo.com/?a=1&utm_source=bar", n.NormalizeString("http://foo.com?utm_source=bar&a=1"))
+}
+
+func TestURLNormalizerNormalizeWithNil(t *testing.T) {
+	n := (*urlNormalizer)(nil)
+
+	assert.Equal(t, "HTTP://Foo.com:80?b&a", n.NormalizeString("HTTP://Foo.com:80?b&a"))
+
+	u := parseURL(t, "http://Foo.com:80")
+	assert.Equal(t, u, n.Normalize(u))
+}
+
+func TestURLNormalizerNormalizeInvalidURL(t *testing.T) {
+	n, err := newURLNormalizer(nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, ":", n.NormalizeString(":"))
+}
+
+func TestURLNormalizerDeduplicateLinks(t *testing.T) {
+	n, err := newURLNormalizer([]string{"utm_*"})
+	assert.Nil(t, err)
+
+	e := errors.New("foo")
+	ls := map[string]error{
+		"http://foo.com/bar?b=2&a=1":        nil,
+		"http://foo.com/bar?a=1&b=2":        nil,
+		"http://foo.com/bar?utm_source=baz": nil,
+		"http://foo.com:80/bar":             nil,
+		"http://foo.com/baz?utm_source=qux": e,
+		"http://foo.com/baz":                nil,
+	}
+	ss := map[string]*linkSource{
+		"http://foo.com/bar?b=2&a=1":        {Element: "a"},
+		"http://foo.com/bar?a=1&b=2":        {Element: "a"},
+		"http://foo.com/bar?utm_source=baz": {Element: "a"},
+		"http://foo.com:80/bar":             {Element: "img", Subresource: true},
+	}
+
+	n.DeduplicateLinks(ls, ss)
+
+	assert.Equal(t, map[string]error{
+		"http://foo.com/bar?a=1&b=2":        nil,
+		"http://foo.com/bar?utm_source=baz": nil,
+		"http://foo.com/baz?utm_source=qux": e,
+		"http://foo.com/baz":                nil,
+	}, ls)
+	assert.Equal(t, map[string]*linkSource{
+		"http://foo.com/bar?a=1&b=2":        {Element: "a"},
+		"http://foo.com/bar?utm_source=baz": {Element: "img", Subresource: true},
+	}, ss)
+}
+
+func TestURLNormalizerDeduplicateLinksWithNil(t *testing.T) {
+	ls := map[string]error{"http://foo.com/bar": nil, "http://foo.com:80/bar": nil}
+
+	(*urlNormalizer)(nil).DeduplicateLinks(ls, nil)
+
+	assert.Equal(t, map[string]error{"http://foo.com/bar": nil, "http://foo.com:80/bar": nil}, ls)
+}
+
+func TestNewURLNormalizerError(t *testing.T) {
+	_, err := newURLNormalizer([]string{"utm_["})
+	assert.Equal(t, "invalid tracking parameter pattern: utm_[", err.Error())
+}
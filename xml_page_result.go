@@ -1,10 +1,18 @@
 package main
 
+import (
+	"strconv"
+	"time"
+)
+
 type xmlPageResult struct {
 	Url      string `xml:"name,attr"`
 	Total    int    `xml:"tests,attr"`
 	Failures int    `xml:"failures,attr"`
 	Skipped  int    `xml:"skipped,attr"`
+	Time     string `xml:"time,attr,omitempty"`
+	// Page warnings are reported as properties of a test suite.
+	Properties *xmlProperties `xml:"properties"`
 	// spell-checker: disable-next-line
 	Links []*xmlLinkResult `xml:"testcase"`
 }
@@ -12,8 +20,13 @@ type xmlPageResult struct {
 type xmlLinkResult struct {
 	Url string `xml:"name,attr"`
 	// spell-checker: disable-next-line
-	Source  string          `xml:"classname,attr"`
-	Failure *xmlLinkFailure `xml:"failure"`
+	Source string `xml:"classname,attr"`
+	File   string `xml:"file,attr,omitempty"`
+	Line   int    `xml:"line,attr,omitempty"`
+	Time   string `xml:"time,attr,omitempty"`
+	// Properties of test cases are not standard but supported by some tools.
+	Properties *xmlProperties  `xml:"properties"`
+	Failure    *xmlLinkFailure `xml:"failure"`
 }
 
 type xmlLinkFailure struct {
@@ -21,35 +34,111 @@ type xmlLinkFailure struct {
 }
 
 func newXMLPageResult(pr *pageResult) *xmlPageResult {
-	ls := make([]*xmlLinkResult, 0, len(pr.SuccessLinkResults)+len(pr.ErrorLinkResults))
+	ls := make([]*xmlLinkResult, 0, len(pr.SuccessLinkResults)+len(pr.WarningLinkResults)+len(pr.ErrorLinkResults))
+	d := time.Duration(0)
 
 	for _, r := range pr.SuccessLinkResults {
-		ls = append(
-			ls,
-			&xmlLinkResult{
-				Url:    r.URL,
-				Source: pr.URL,
-			},
-		)
+		ls = append(ls, newXMLSuccessLinkResult(pr.URL, r, nil))
+		d += r.Duration
+	}
+
+	for _, r := range pr.WarningLinkResults {
+		ls = append(ls, newXMLSuccessLinkResult(pr.URL, &r.successLinkResult, r.Warnings))
+		d += r.Duration
 	}
 
 	for _, r := range pr.ErrorLinkResults {
 		ls = append(
 			ls,
 			&xmlLinkResult{
-				Url:     r.URL,
-				Source:  pr.URL,
-				Failure: &xmlLinkFailure{Message: r.Error.Error()},
+				Url:        r.URL,
+				Source:     pr.URL,
+				File:       xmlLinkFile(pr.URL, r.Source),
+				Line:       linkSourceLine(r.Source),
+				Properties: newXMLProperties(newXMLLinkSourceProperties(r.Source)),
+				Failure:    &xmlLinkFailure{Message: r.Error.Error()},
 			},
 		)
 	}
 
+	ps := []*xmlProperty(nil)
+
+	for _, w := range pr.Warnings {
+		ps = append(ps, &xmlProperty{"warning", w})
+	}
+
 	return &xmlPageResult{
 		Url: pr.URL,
 		// TODO: Consider adding information skipped links, if that can be tracked.
-		Skipped:  0,
-		Total:    len(ls),
-		Failures: len(pr.ErrorLinkResults),
-		Links:    ls,
+		Skipped:    0,
+		Total:      len(ls),
+		Failures:   len(pr.ErrorLinkResults),
+		Time:       formatXMLDuration(d),
+		Properties: newXMLProperties(ps),
+		Links:      ls,
+	}
+}
+
+// formatXMLDuration formats a duration in seconds omitting zero durations.
+func formatXMLDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+
+	return formatSeconds(d)
+}
+
+// xmlLinkFile returns a page URL as a file name only if a link's line is known.
+func xmlLinkFile(page string, s *linkSource) string {
+	if linkSourceLine(s) == 0 {
+		return ""
+	}
+
+	return page
+}
+
+func newXMLSuccessLinkResult(page string, r *successLinkResult, warnings []string) *xmlLinkResult {
+	ps := newXMLLinkSourceProperties(r.Source)
+
+	if r.RedirectURL != "" {
+		ps = append(ps, &xmlProperty{"redirect_url", r.RedirectURL})
+	}
+
+	for _, w := range warnings {
+		ps = append(ps, &xmlProperty{"warning", w})
+	}
+
+	return &xmlLinkResult{
+		Url:        r.URL,
+		Source:     page,
+		File:       xmlLinkFile(page, r.Source),
+		Line:       linkSourceLine(r.Source),
+		Time:       formatXMLDuration(r.Duration),
+		Properties: newXMLProperties(ps),
 	}
 }
+
+func newXMLLinkSourceProperties(s *linkSource) []*xmlProperty {
+	if s == nil {
+		return nil
+	}
+
+	ps := []*xmlProperty{
+		{"element", s.Element},
+		{"attribute", s.Attribute},
+	}
+
+	if s.Line != 0 {
+		ps = append(
+			ps,
+			&xmlProperty{"line", strconv.Itoa(s.Line)},
+			&xmlProperty{"column", strconv.Itoa(s.Column)},
+		)
+	}
+
+	if s.Text != "" {
+		ps = append(ps, &xmlProperty{"text", s.Text})
+	}
+
+	return ps
+}
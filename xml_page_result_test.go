@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/bradleyjkemp/cupaloy"
 	"github.com/stretchr/testify/assert"
@@ -12,10 +13,10 @@ import (
 func TestMarshalErrorXMLPageResult(t *testing.T) {
 	bs, err := marshalXML(newXMLPageResult(
 		&pageResult{
-			"http://foo.com",
-			[]*successLinkResult{},
-			[]*errorLinkResult{
-				{"http://foo.com/bar", errors.New("baz")},
+			URL:                "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{},
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("baz")},
 			},
 		}))
 	assert.Nil(t, err)
@@ -25,11 +26,53 @@ func TestMarshalErrorXMLPageResult(t *testing.T) {
 func TestMarshalSuccessXMLPageResult(t *testing.T) {
 	bs, err := marshalXML(newXMLPageResult(
 		&pageResult{
-			"http://foo.com",
-			[]*successLinkResult{
-				{"http://foo.com/bar", 200},
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/bar", StatusCode: 200},
 			},
-			[]*errorLinkResult{},
+			ErrorLinkResults: []*errorLinkResult{},
+		}))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestMarshalXMLPageResultWithDurations(t *testing.T) {
+	bs, err := marshalXML(newXMLPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200, Duration: 250 * time.Millisecond},
+				{URL: "http://foo.com/bar", StatusCode: 200, Duration: time.Second},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
+		}))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestMarshalXMLPageResultWithLinkSources(t *testing.T) {
+	bs, err := marshalXML(newXMLPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200, Source: &linkSource{Element: "a", Attribute: "href"}},
+			},
+			ErrorLinkResults: []*errorLinkResult{
+				{URL: "http://foo.com/bar", Error: errors.New("baz"), Source: &linkSource{Line: 42, Column: 3, Element: "a", Attribute: "href", Text: "qux"}},
+			},
+		}))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestMarshalRedirectedXMLPageResult(t *testing.T) {
+	bs, err := marshalXML(newXMLPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			SuccessLinkResults: []*successLinkResult{
+				{URL: "http://foo.com/foo", StatusCode: 200, RedirectURL: "http://foo.com/bar"},
+			},
+			ErrorLinkResults: []*errorLinkResult{},
 		}))
 	assert.Nil(t, err)
 	cupaloy.SnapshotT(t, bs)
@@ -38,3 +81,23 @@ func TestMarshalSuccessXMLPageResult(t *testing.T) {
 func marshalXML(x any) ([]byte, error) {
 	return xml.MarshalIndent(x, "", "  ")
 }
+
+func TestMarshalWarningXMLPageResult(t *testing.T) {
+	bs, err := marshalXML(newXMLPageResult(
+		&pageResult{
+			URL: "http://foo.com",
+			WarningLinkResults: []*warningLinkResult{
+				{successLinkResult{URL: "http://foo.com/foo", StatusCode: 200}, []string{"foo"}},
+			},
+		}))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
+
+func TestMarshalPageWarningXMLPageResult(t *testing.T) {
+	bs, err := marshalXML(newXMLPageResult(
+		&pageResult{URL: "http://foo.com", Warnings: []string{"duplicate id #foo"}},
+	))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}
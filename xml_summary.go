@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+type xmlProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type xmlProperties struct {
+	Properties []*xmlProperty `xml:"property"`
+}
+
+// newXMLProperties wraps properties omitting an empty list of them.
+func newXMLProperties(ps []*xmlProperty) *xmlProperties {
+	if len(ps) == 0 {
+		return nil
+	}
+
+	return &xmlProperties{ps}
+}
+
+func newXMLSummaryProperties(s *summary) []*xmlProperty {
+	ps := []*xmlProperty{
+		{"pages", strconv.Itoa(s.Pages)},
+		{"links", strconv.Itoa(s.Links)},
+		{"successes", strconv.Itoa(s.Successes)},
+		{"warnings", strconv.Itoa(s.Warnings)},
+		{"failures", strconv.Itoa(s.Failures)},
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(s.Categories)) {
+		ps = append(ps, &xmlProperty{"failures." + k, strconv.Itoa(s.Categories[k])})
+	}
+
+	for _, c := range slices.Sorted(maps.Keys(s.StatusCodes)) {
+		ps = append(ps, &xmlProperty{fmt.Sprintf("status_codes.%v", c), strconv.Itoa(s.StatusCodes[c])})
+	}
+
+	for _, h := range s.FailingHosts {
+		ps = append(ps, &xmlProperty{"failing_hosts." + h.Name, strconv.Itoa(h.Failures)})
+	}
+
+	return append(
+		ps,
+		&xmlProperty{"bytes", strconv.Itoa(s.Bytes)},
+		&xmlProperty{"duration", formatSeconds(s.Duration)},
+	)
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bradleyjkemp/cupaloy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalXMLSummaryProperties(t *testing.T) {
+	bs, err := marshalXML(newXMLSummaryProperties(newTestSummary()))
+	assert.Nil(t, err)
+	cupaloy.SnapshotT(t, bs)
+}